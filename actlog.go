@@ -0,0 +1,233 @@
+package gospoc
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultActivityLogRange        = time.Hour
+	defaultActivityLogPollInterval = 10 * time.Second
+)
+
+// ActivityLog is an interface for reading the
+// IBM Spectrum Protect activity log
+type ActivityLog interface {
+	Follow(ctx context.Context, opts *ActivityLogFollowOptions, filter *ActivityLogFilter) (<-chan ActivityLogEntry, error)
+	Query(ctx context.Context, serverName string, filter *ActivityLogFilter) ([]ActivityLogEntry, *http.Response, error)
+}
+
+// ActivityLogOp handles communication with the activity log related methods of the
+// IBM Spectrum Protect Operations Center REST API
+type ActivityLogOp struct {
+	client *Client
+}
+
+// ActivityLogEntry is a message of the activity log
+type ActivityLogEntry struct {
+	Server        string          `spoc:"-"`
+	Time          time.Time       `spoc:"DATE_TIME"`
+	MessageNumber string          `spoc:"-"`
+	Severity      MessageSeverity `spoc:"-"`
+	Message       string          `spoc:"MESSAGE,nullzero"`
+	Originator    string          `spoc:"ORIGINATOR,nullzero"`
+	Client        string          `spoc:"NODENAME,nullzero"`
+	Owner         string          `spoc:"OWNERNAME,nullzero"`
+	Schedule      string          `spoc:"SCHEDNAME,nullzero"`
+	Domain        string          `spoc:"DOMAINNAME,nullzero"`
+	RemoteServer  string          `spoc:"SERVERNAME,nullzero"`
+	Session       int64           `spoc:"SESSION,nullzero"`
+	Process       int64           `spoc:"PROCESS,nullzero"`
+}
+
+// ActivityLogFilter selects activity log messages. Empty fields match every message.
+type ActivityLogFilter struct {
+	// Begin and End limit the time of the messages. Begin defaults to one hour ago.
+	Begin time.Time
+	End   time.Time
+
+	// MessageNumber is the number of the message, such as 985 for ANR0985I
+	MessageNumber int
+	Severity      MessageSeverity
+	Client        string
+	Session       int64
+	Process       int64
+
+	// Search matches the messages containing the string, ignoring case.
+	// The % and _ characters match any string and any character.
+	Search string
+}
+
+// ActivityLogFollowOptions controls how ActivityLog.Follow polls the servers
+type ActivityLogFollowOptions struct {
+	// Servers to follow. When empty, every server returned by
+	// BackupServers.List that matches Filter is followed.
+	Servers []string
+
+	// Filter selects the servers when Servers is empty
+	Filter *ServerFilter
+
+	// Since is the time of the first message. It defaults to now.
+	Since time.Time
+
+	// PollInterval is the time between polls. It defaults to 10 seconds.
+	PollInterval time.Duration
+
+	// OnError is called when a poll fails. Following continues with the next poll.
+	OnError func(serverName string, err error)
+}
+
+// Query the activity log of a backup server. Entries are returned oldest first.
+func (s *ActivityLogOp) Query(ctx context.Context, serverName string, filter *ActivityLogFilter) ([]ActivityLogEntry, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if filter == nil {
+		filter = new(ActivityLogFilter)
+	}
+
+	var entries []ActivityLogEntry
+	resp, err := s.client.CLI.Query(ctx, serverName, filter.query(), &entries)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	for i := range entries {
+		entries[i].Server = serverName
+
+		m := parseMessage(entries[i].Message)
+		entries[i].MessageNumber = m.Number
+		entries[i].Severity = m.Severity
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time.Before(entries[j].Time) })
+
+	return entries, resp, err
+}
+
+// Follow streams the new activity log messages of one or many servers, like tail -f.
+// Each server is polled for the messages logged since the last one received. The
+// channel is closed once ctx is done.
+func (s *ActivityLogOp) Follow(ctx context.Context, opts *ActivityLogFollowOptions, filter *ActivityLogFilter) (<-chan ActivityLogEntry, error) {
+	if opts == nil {
+		opts = new(ActivityLogFollowOptions)
+	}
+
+	servers, err := s.client.fanOutServers(ctx, &FanOutOptions{Servers: opts.Servers, Filter: opts.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(servers) == 0 {
+		return nil, NewArgError("opts", "does not select any server")
+	}
+
+	since := opts.Since
+	if since.IsZero() {
+		since = time.Now()
+	}
+
+	entries := make(chan ActivityLogEntry)
+
+	var wg sync.WaitGroup
+	for _, serverName := range servers {
+		wg.Add(1)
+		go func(serverName string) {
+			defer wg.Done()
+			s.follow(ctx, serverName, opts, filter, since, entries)
+		}(serverName)
+	}
+
+	go func() {
+		wg.Wait()
+		close(entries)
+	}()
+
+	return entries, nil
+}
+
+func (s *ActivityLogOp) follow(ctx context.Context, serverName string, opts *ActivityLogFollowOptions, filter *ActivityLogFilter, since time.Time, entries chan<- ActivityLogEntry) {
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultActivityLogPollInterval
+	}
+
+	f := ActivityLogFilter{}
+	if filter != nil {
+		f = *filter
+	}
+	f.Begin, f.End = since, time.Time{}
+
+	// seen holds the entries logged at the watermark, which the next poll returns again
+	seen := make(map[ActivityLogEntry]bool)
+
+	for {
+		got, _, err := s.Query(ctx, serverName, &f)
+		if err != nil && ctx.Err() == nil && opts.OnError != nil {
+			opts.OnError(serverName, err)
+		}
+
+		for _, entry := range got {
+			if entry.Time.Before(f.Begin) || seen[entry] {
+				continue
+			}
+
+			if entry.Time.After(f.Begin) {
+				f.Begin = entry.Time
+				seen = make(map[ActivityLogEntry]bool)
+			}
+			seen[entry] = true
+
+			select {
+			case entries <- entry:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if sleepContext(ctx, interval) != nil {
+			return
+		}
+	}
+}
+
+func (f *ActivityLogFilter) query() string {
+	begin := f.Begin
+	if begin.IsZero() {
+		begin = time.Now().Add(-defaultActivityLogRange)
+	}
+
+	conditions := []string{
+		sqlTime("DATE_TIME", ">=", begin),
+		sqlTime("DATE_TIME", "<", f.End),
+		sqlName("NODENAME", f.Client),
+	}
+
+	if f.MessageNumber > 0 {
+		conditions = append(conditions, "MSGNO="+strconv.Itoa(f.MessageNumber))
+	}
+
+	if f.Severity != "" {
+		conditions = append(conditions, sqlName("SEVERITY", string(f.Severity)))
+	}
+
+	if f.Session > 0 {
+		conditions = append(conditions, "SESSION="+strconv.FormatInt(f.Session, 10))
+	}
+
+	if f.Process > 0 {
+		conditions = append(conditions, "PROCESS="+strconv.FormatInt(f.Process, 10))
+	}
+
+	if f.Search != "" {
+		conditions = append(conditions, "UPPER(MESSAGE) LIKE "+quoteSQL("%"+strings.ToUpper(f.Search)+"%"))
+	}
+
+	return selectFrom("ACTLOG", conditions...)
+}
@@ -0,0 +1,359 @@
+package gospoc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// AuthorityClass is a class of administrative authority
+type AuthorityClass string
+
+// Authority classes
+const (
+	AuthoritySystem   AuthorityClass = "SYSTEM"
+	AuthorityPolicy   AuthorityClass = "POLICY"
+	AuthorityStorage  AuthorityClass = "STORAGE"
+	AuthorityOperator AuthorityClass = "OPERATOR"
+)
+
+// Administrators is an interface for interacting with
+// IBM Spectrum Protect administrators
+type Administrators interface {
+	Get(ctx context.Context, serverName string, adminName string) (*Administrator, *http.Response, error)
+	GrantAuthority(ctx context.Context, serverName string, adminName string, authority *AuthorityRequest) (*http.Response, error)
+	List(ctx context.Context, serverName string) ([]Administrator, *http.Response, error)
+	Lock(ctx context.Context, serverName string, adminName string) (*http.Response, error)
+	Register(ctx context.Context, serverName string, createRequest *AdministratorRequest) (*http.Response, error)
+	Remove(ctx context.Context, serverName string, adminName string) (*http.Response, error)
+	Rename(ctx context.Context, serverName string, adminName string, newName string) (*http.Response, error)
+	RevokeAuthority(ctx context.Context, serverName string, adminName string, authority *AuthorityRequest) (*http.Response, error)
+	Unlock(ctx context.Context, serverName string, adminName string) (*http.Response, error)
+	Update(ctx context.Context, serverName string, update *AdministratorRequest) (*http.Response, error)
+}
+
+// AdministratorsOp handles communication with the administrator related methods of the
+// IBM Spectrum Protect Operations Center REST API
+type AdministratorsOp struct {
+	client *Client
+}
+
+// Administrator contains the elements that make up an administrator
+type Administrator struct {
+	Name             string
+	Contact          string
+	Email            string
+	Locked           bool
+	Authentication   string
+	LastAccess       time.Time
+	PasswordSet      time.Time
+	InvalidPasswords int
+	Registered       time.Time
+	RegisteredBy     string
+	Profile          string
+
+	// Authority lists the classes granted to the administrator. Policy and storage
+	// authority are unrestricted unless PolicyDomains or StoragePools list their scope.
+	Authority     []AuthorityClass
+	PolicyDomains []string
+	StoragePools  []string
+}
+
+// HasAuthority reports whether the administrator was granted an authority class
+func (a *Administrator) HasAuthority(class AuthorityClass) bool {
+	for _, c := range a.Authority {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// AdministratorRequest represents a request to register or update an administrator.
+// Empty fields are left at their defaults on register and unchanged on update.
+type AdministratorRequest struct {
+	Name string
+
+	// Password is required to register an administrator. It is never
+	// reported in command results or errors.
+	Password string
+
+	Contact string
+	Email   string
+
+	// Authentication is LOCAL or LDAP
+	Authentication     string
+	PasswordExpiration *int
+	ForcePasswordReset *bool
+	SessionSecurity    string
+}
+
+// AuthorityRequest represents authority granted to or revoked from an administrator
+type AuthorityRequest struct {
+	Classes []AuthorityClass
+
+	// Domains scopes policy authority to policy domains
+	Domains []string
+
+	// StoragePools scopes storage authority to storage pools
+	StoragePools []string
+}
+
+type administratorRow struct {
+	Name             string    `spoc:"ADMIN_NAME"`
+	Contact          string    `spoc:"CONTACT,nullzero"`
+	Email            string    `spoc:"EMAILADDRESS,nullzero"`
+	Locked           bool      `spoc:"LOCKED,nullzero"`
+	Authentication   string    `spoc:"AUTHENTICATION,nullzero"`
+	LastAccess       time.Time `spoc:"LASTACC_TIME,nullzero"`
+	PasswordSet      time.Time `spoc:"PWSET_TIME,nullzero"`
+	InvalidPasswords int       `spoc:"INVALID_PW_COUNT,nullzero"`
+	Registered       time.Time `spoc:"REG_TIME,nullzero"`
+	RegisteredBy     string    `spoc:"REG_ADMIN,nullzero"`
+	Profile          string    `spoc:"PROFILE,nullzero"`
+	SystemPriv       string    `spoc:"SYSTEM_PRIV,nullzero"`
+	PolicyPriv       string    `spoc:"POLICY_PRIV,nullzero"`
+	StoragePriv      string    `spoc:"STORAGE_PRIV,nullzero"`
+	OperatorPriv     string    `spoc:"OPERATOR_PRIV,nullzero"`
+}
+
+// List all administrators of a backup server along with their authority
+func (s *AdministratorsOp) List(ctx context.Context, serverName string) ([]Administrator, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	return s.list(ctx, serverName, "")
+}
+
+// Get an administrator along with its authority
+func (s *AdministratorsOp) Get(ctx context.Context, serverName string, adminName string) (*Administrator, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if adminName == "" {
+		return nil, nil, NewArgError("adminName", "cannot be empty")
+	}
+
+	admins, resp, err := s.list(ctx, serverName, adminName)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(admins) == 0 {
+		return nil, resp, notFound("administrator "+adminName, serverName)
+	}
+
+	return &admins[0], resp, err
+}
+
+func (s *AdministratorsOp) list(ctx context.Context, serverName string, adminName string) ([]Administrator, *http.Response, error) {
+	var rows []administratorRow
+	resp, err := s.client.CLI.Query(ctx, serverName, selectFrom("ADMINS", sqlName("ADMIN_NAME", adminName)), &rows)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	admins := make([]Administrator, len(rows))
+	for i, row := range rows {
+		admins[i] = row.administrator()
+	}
+
+	return admins, resp, err
+}
+
+// Register a new administrator. Authority is granted separately with GrantAuthority.
+func (s *AdministratorsOp) Register(ctx context.Context, serverName string, createRequest *AdministratorRequest) (*http.Response, error) {
+	if createRequest != nil && createRequest.Password == "" {
+		return nil, NewArgError("createRequest.Password", "cannot be empty")
+	}
+
+	return s.adminCommand(ctx, "REGISTER", serverName, createRequest, "createRequest")
+}
+
+// Update an administrator, changing its password when update.Password is set
+func (s *AdministratorsOp) Update(ctx context.Context, serverName string, update *AdministratorRequest) (*http.Response, error) {
+	return s.adminCommand(ctx, "UPDATE", serverName, update, "update")
+}
+
+func (s *AdministratorsOp) adminCommand(ctx context.Context, verb string, serverName string, r *AdministratorRequest, argName string) (*http.Response, error) {
+	if r == nil {
+		return nil, NewArgError(argName, "cannot be nil")
+	}
+
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if r.Name == "" {
+		return nil, NewArgError(argName+".Name", "cannot be empty")
+	}
+
+	cmd := newCommand(verb, "ADMIN").arg(r.Name)
+	if r.Password != "" {
+		cmd.secret(r.Password)
+	}
+	cmd.param("CONTACT", r.Contact).
+		param("EMAIL", r.Email).
+		param("AUTHENTICATION", r.Authentication).
+		paramInt("PASSEXP", r.PasswordExpiration).
+		paramBool("FORCEPWRESET", r.ForcePasswordReset).
+		param("SESSIONSECURITY", r.SessionSecurity)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// Lock an administrator out of the server
+func (s *AdministratorsOp) Lock(ctx context.Context, serverName string, adminName string) (*http.Response, error) {
+	return s.nameCommand(ctx, "LOCK", serverName, adminName, false)
+}
+
+// Unlock an administrator
+func (s *AdministratorsOp) Unlock(ctx context.Context, serverName string, adminName string) (*http.Response, error) {
+	return s.nameCommand(ctx, "UNLOCK", serverName, adminName, false)
+}
+
+// Remove an administrator from the server
+func (s *AdministratorsOp) Remove(ctx context.Context, serverName string, adminName string) (*http.Response, error) {
+	return s.nameCommand(ctx, "REMOVE", serverName, adminName, true)
+}
+
+func (s *AdministratorsOp) nameCommand(ctx context.Context, verb string, serverName string, adminName string, confirmed bool) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if adminName == "" {
+		return nil, NewArgError("adminName", "cannot be empty")
+	}
+
+	cmd := newCommand(verb, "ADMIN").arg(adminName)
+
+	if confirmed {
+		_, resp, err := s.client.runConfirmedCommand(ctx, serverName, cmd)
+		return resp, err
+	}
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// Rename an administrator
+func (s *AdministratorsOp) Rename(ctx context.Context, serverName string, adminName string, newName string) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if adminName == "" {
+		return nil, NewArgError("adminName", "cannot be empty")
+	}
+
+	if newName == "" {
+		return nil, NewArgError("newName", "cannot be empty")
+	}
+
+	cmd := newCommand("RENAME", "ADMIN").arg(adminName).arg(newName)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// GrantAuthority grants authority classes to an administrator, optionally scoped to
+// policy domains and storage pools
+func (s *AdministratorsOp) GrantAuthority(ctx context.Context, serverName string, adminName string, authority *AuthorityRequest) (*http.Response, error) {
+	return s.authorityCommand(ctx, "GRANT", serverName, adminName, authority)
+}
+
+// RevokeAuthority revokes authority classes from an administrator, or only its
+// authority over some policy domains and storage pools
+func (s *AdministratorsOp) RevokeAuthority(ctx context.Context, serverName string, adminName string, authority *AuthorityRequest) (*http.Response, error) {
+	return s.authorityCommand(ctx, "REVOKE", serverName, adminName, authority)
+}
+
+func (s *AdministratorsOp) authorityCommand(ctx context.Context, verb string, serverName string, adminName string, authority *AuthorityRequest) (*http.Response, error) {
+	if authority == nil {
+		return nil, NewArgError("authority", "cannot be nil")
+	}
+
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if adminName == "" {
+		return nil, NewArgError("adminName", "cannot be empty")
+	}
+
+	if len(authority.Classes) == 0 && len(authority.Domains) == 0 && len(authority.StoragePools) == 0 {
+		return nil, NewArgError("authority", "must list classes, domains or storage pools")
+	}
+
+	classes := make([]string, len(authority.Classes))
+	for i, class := range authority.Classes {
+		classes[i] = string(class)
+	}
+
+	cmd := newCommand(verb, "AUTHORITY").arg(adminName).
+		paramList("CLASSES", classes).
+		paramList("DOMAINS", authority.Domains).
+		paramList("STGPOOLS", authority.StoragePools)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+func (r *administratorRow) administrator() Administrator {
+	admin := Administrator{
+		Name:             r.Name,
+		Contact:          r.Contact,
+		Email:            r.Email,
+		Locked:           r.Locked,
+		Authentication:   r.Authentication,
+		LastAccess:       r.LastAccess,
+		PasswordSet:      r.PasswordSet,
+		InvalidPasswords: r.InvalidPasswords,
+		Registered:       r.Registered,
+		RegisteredBy:     r.RegisteredBy,
+		Profile:          r.Profile,
+	}
+
+	privileges := []struct {
+		class AuthorityClass
+		value string
+		scope *[]string
+	}{
+		{AuthoritySystem, r.SystemPriv, nil},
+		{AuthorityPolicy, r.PolicyPriv, &admin.PolicyDomains},
+		{AuthorityStorage, r.StoragePriv, &admin.StoragePools},
+		{AuthorityOperator, r.OperatorPriv, nil},
+	}
+
+	for _, p := range privileges {
+		granted, scope := parsePrivilege(p.value)
+		if !granted {
+			continue
+		}
+		admin.Authority = append(admin.Authority, p.class)
+		if p.scope != nil {
+			*p.scope = scope
+		}
+	}
+
+	return admin
+}
+
+// parsePrivilege parses a privilege column of the ADMINS table, which holds No, Yes,
+// a note such as **Unrestricted**, or the names of the objects the privilege is scoped to
+func parsePrivilege(value string) (bool, []string) {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "" || strings.EqualFold(value, "NO"):
+		return false, nil
+	case strings.EqualFold(value, "YES") || strings.HasPrefix(value, "**"):
+		return true, nil
+	}
+
+	return true, strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' })
+}
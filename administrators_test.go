@@ -0,0 +1,97 @@
+package gospoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// requestBody returns whatever can still be read from the body of req
+func requestBody(req *http.Request) string {
+	var body []byte
+	if req.Body != nil {
+		body, _ = ioutil.ReadAll(req.Body)
+	}
+	if req.GetBody != nil {
+		if rc, err := req.GetBody(); err == nil {
+			replayed, _ := ioutil.ReadAll(rc)
+			body = append(body, replayed...)
+		}
+	}
+	return string(body)
+}
+
+func TestAdministrators_RegisterRedactsPassword(t *testing.T) {
+	for _, status := range []int{http.StatusInternalServerError, http.StatusOK} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			mux := http.NewServeMux()
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			// The body of the response echoes the command back
+			mux.HandleFunc(cliBasePath+"/issueCommand/SERVER1", func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+				if status != http.StatusOK {
+					http.Error(w, "Unable to issue "+string(body), status)
+					return
+				}
+				fmt.Fprintf(w, `{"MESSAGES":["ANR2017I Administrator ADMIN issued command: %s"],"RC":0}`, body)
+			})
+
+			var callbackBodies []string
+			client, err := NewClientWithOptions(&Config{Username: "admin", Password: "secret"},
+				WithBaseURL(server.URL), WithRetryPolicy(nil),
+				WithRequestCompletedCallback(func(req *http.Request, resp *http.Response) {
+					callbackBodies = append(callbackBodies, requestBody(req), requestBody(resp.Request))
+				}))
+			if err != nil {
+				t.Fatalf("NewClientWithOptions returned error: %v", err)
+			}
+
+			resp, err := client.Administrators.Register(context.Background(), "SERVER1", &AdministratorRequest{
+				Name:     "JDOE",
+				Password: "Pa55word",
+			})
+
+			if status != http.StatusOK {
+				if err == nil {
+					t.Fatal("Administrators.Register against a failing server did not return an error")
+				}
+				if strings.Contains(err.Error(), "Pa55word") {
+					t.Errorf("Administrators.Register returned %q, which contains the password", err)
+				}
+
+				var errResp *ErrorResponse
+				if !errors.As(err, &errResp) {
+					t.Fatalf("Administrators.Register returned %#v, expected an ErrorResponse", err)
+				}
+				if body := requestBody(errResp.Response.Request); strings.Contains(body, "Pa55word") {
+					t.Errorf("Request of the ErrorResponse has the body %q, which contains the password", body)
+				}
+			} else if err != nil {
+				t.Fatalf("Administrators.Register returned error: %v", err)
+			}
+
+			if resp == nil || resp.Request == nil {
+				t.Fatal("Administrators.Register did not return the response")
+			}
+			if body := requestBody(resp.Request); strings.Contains(body, "Pa55word") {
+				t.Errorf("Request of the returned response has the body %q, which contains the password", body)
+			}
+
+			if len(callbackBodies) == 0 {
+				t.Fatal("Request completion callback was not called")
+			}
+			for _, body := range callbackBodies {
+				if strings.Contains(body, "Pa55word") {
+					t.Errorf("Request completion callback was given the body %q, which contains the password", body)
+				}
+			}
+		})
+	}
+}
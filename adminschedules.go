@@ -0,0 +1,177 @@
+package gospoc
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// AdminSchedules is an interface for interacting with
+// IBM Spectrum Protect administrative schedules
+type AdminSchedules interface {
+	Create(ctx context.Context, serverName string, createRequest *AdminScheduleRequest) (*http.Response, error)
+	Delete(ctx context.Context, serverName string, scheduleName string) (*http.Response, error)
+	Get(ctx context.Context, serverName string, scheduleName string) (*AdminSchedule, *http.Response, error)
+	List(ctx context.Context, serverName string) ([]AdminSchedule, *http.Response, error)
+	Run(ctx context.Context, serverName string, scheduleName string) (*CommandResult, *http.Response, error)
+	Update(ctx context.Context, serverName string, update *AdminScheduleRequest) (*http.Response, error)
+}
+
+// AdminSchedulesOp handles communication with the administrative schedule related methods of the
+// IBM Spectrum Protect Operations Center REST API
+type AdminSchedulesOp struct {
+	client *Client
+}
+
+// AdminSchedule contains the elements that make up an administrative schedule
+type AdminSchedule struct {
+	Name          string    `spoc:"SCHEDULE_NAME"`
+	Description   string    `spoc:"DESCRIPTION,nullzero"`
+	Command       string    `spoc:"COMMAND,nullzero"`
+	Priority      int       `spoc:"PRIORITY,nullzero"`
+	StartDate     string    `spoc:"STARTDATE,nullzero"`
+	StartTime     string    `spoc:"STARTTIME,nullzero"`
+	Duration      int       `spoc:"DURATION,nullzero"`
+	DurationUnits string    `spoc:"DURUNITS,nullzero"`
+	Period        int       `spoc:"PERIOD,nullzero"`
+	PeriodUnits   string    `spoc:"PERUNITS,nullzero"`
+	DayOfWeek     string    `spoc:"DAYOFWEEK,nullzero"`
+	Expiration    string    `spoc:"EXPIRATION,nullzero"`
+	Active        bool      `spoc:"ACTIVE,nullzero"`
+	Style         string    `spoc:"SCHED_STYLE,nullzero"`
+	ChangedBy     string    `spoc:"CHG_ADMIN,nullzero"`
+	ChangedAt     time.Time `spoc:"CHG_TIME,nullzero"`
+	Profile       string    `spoc:"PROFILE,nullzero"`
+}
+
+// AdminScheduleRequest represents a request to define or update an administrative schedule.
+// Empty fields are left at their defaults on create and unchanged on update.
+type AdminScheduleRequest struct {
+	Name        string
+	Description string
+
+	// Command is the administrative command run by the schedule
+	Command string
+	Active  *bool
+
+	Priority      *int
+	StartDate     string
+	StartTime     string
+	Duration      *int
+	DurationUnits string
+	Period        *int
+	PeriodUnits   string
+	DayOfWeek     string
+	Expiration    string
+}
+
+// List all administrative schedules of a backup server
+func (s *AdminSchedulesOp) List(ctx context.Context, serverName string) ([]AdminSchedule, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	var schedules []AdminSchedule
+	resp, err := s.client.CLI.Query(ctx, serverName, selectFrom("ADMIN_SCHEDULES"), &schedules)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return schedules, resp, err
+}
+
+// Get the details of a specific administrative schedule
+func (s *AdminSchedulesOp) Get(ctx context.Context, serverName string, scheduleName string) (*AdminSchedule, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if scheduleName == "" {
+		return nil, nil, NewArgError("scheduleName", "cannot be empty")
+	}
+
+	var schedules []AdminSchedule
+	query := selectFrom("ADMIN_SCHEDULES", sqlName("SCHEDULE_NAME", scheduleName))
+	resp, err := s.client.CLI.Query(ctx, serverName, query, &schedules)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(schedules) == 0 {
+		return nil, resp, notFound("administrative schedule "+scheduleName, serverName)
+	}
+
+	return &schedules[0], resp, err
+}
+
+// Create defines a new administrative schedule
+func (s *AdminSchedulesOp) Create(ctx context.Context, serverName string, createRequest *AdminScheduleRequest) (*http.Response, error) {
+	if createRequest != nil && createRequest.Command == "" {
+		return nil, NewArgError("createRequest.Command", "cannot be empty")
+	}
+
+	return s.scheduleCommand(ctx, "DEFINE", serverName, createRequest, "createRequest")
+}
+
+// Update the settings of an administrative schedule
+func (s *AdminSchedulesOp) Update(ctx context.Context, serverName string, update *AdminScheduleRequest) (*http.Response, error) {
+	return s.scheduleCommand(ctx, "UPDATE", serverName, update, "update")
+}
+
+func (s *AdminSchedulesOp) scheduleCommand(ctx context.Context, verb string, serverName string, r *AdminScheduleRequest, argName string) (*http.Response, error) {
+	if r == nil {
+		return nil, NewArgError(argName, "cannot be nil")
+	}
+
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if r.Name == "" {
+		return nil, NewArgError(argName+".Name", "cannot be empty")
+	}
+
+	cmd := newCommand(verb, "SCHEDULE").arg(r.Name).
+		param("TYPE", "ADMINISTRATIVE").
+		param("CMD", r.Command).
+		paramBool("ACTIVE", r.Active).
+		param("DESCRIPTION", r.Description).
+		paramInt("PRIORITY", r.Priority).
+		param("STARTDATE", r.StartDate).
+		param("STARTTIME", r.StartTime).
+		paramInt("DURATION", r.Duration).
+		param("DURUNITS", r.DurationUnits).
+		paramInt("PERIOD", r.Period).
+		param("PERUNITS", r.PeriodUnits).
+		paramList("DAYOFWEEK", splitList(r.DayOfWeek)).
+		param("EXPIRATION", r.Expiration)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// Delete an administrative schedule
+func (s *AdminSchedulesOp) Delete(ctx context.Context, serverName string, scheduleName string) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if scheduleName == "" {
+		return nil, NewArgError("scheduleName", "cannot be empty")
+	}
+
+	cmd := newCommand("DELETE", "SCHEDULE").arg(scheduleName).param("TYPE", "ADMINISTRATIVE")
+
+	_, resp, err := s.client.runConfirmedCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// Run issues the command of an administrative schedule right away, outside of its schedule
+func (s *AdminSchedulesOp) Run(ctx context.Context, serverName string, scheduleName string) (*CommandResult, *http.Response, error) {
+	schedule, resp, err := s.Get(ctx, serverName, scheduleName)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return s.client.CLI.IssueConfirmCommand(ctx, serverName, schedule.Command)
+}
@@ -0,0 +1,147 @@
+package gospoc
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Alert statuses
+const (
+	AlertActive   = "ACTIVE"
+	AlertInactive = "INACTIVE"
+	AlertClosed   = "CLOSED"
+)
+
+// Alerts is an interface for interacting with
+// IBM Spectrum Protect alerts
+type Alerts interface {
+	Acknowledge(ctx context.Context, serverName string, alertID int64, remark string) (*http.Response, error)
+	Assign(ctx context.Context, serverName string, alertID int64, adminName string) (*http.Response, error)
+	Close(ctx context.Context, serverName string, alertID int64, remark string) (*http.Response, error)
+	List(ctx context.Context, serverName string, filter *AlertFilter) ([]Alert, *http.Response, error)
+	ListAll(ctx context.Context, opts *FanOutOptions, filter *AlertFilter) ([]Alert, error)
+}
+
+// AlertsOp handles communication with the alert related methods of the
+// IBM Spectrum Protect Operations Center REST API
+type AlertsOp struct {
+	client *Client
+}
+
+// Alert contains the elements that make up an alert
+type Alert struct {
+	Server          string
+	ID              int64           `spoc:"ALERT_ID"`
+	MessageNumber   string          `spoc:"-"`
+	Severity        MessageSeverity `spoc:"-"`
+	Message         string          `spoc:"MESSAGE,nullzero"`
+	Category        string          `spoc:"CATEGORY,nullzero"`
+	Status          string          `spoc:"STATUS,nullzero"`
+	SourceName      string          `spoc:"SOURCE_NAME,nullzero"`
+	SourceType      string          `spoc:"SOURCE_TYPE,nullzero"`
+	FirstOccurrence time.Time       `spoc:"FIRST_OCCURRENCE,nullzero"`
+	LastOccurrence  time.Time       `spoc:"LAST_OCCURRENCE,nullzero"`
+	Count           int             `spoc:"COUNT,nullzero"`
+	Assigned        string          `spoc:"ASSIGNED,nullzero"`
+	ResolvedBy      string          `spoc:"RESOLVED_BY,nullzero"`
+	Remark          string          `spoc:"REMARK,nullzero"`
+}
+
+// AlertFilter selects alerts. Empty fields match every alert.
+type AlertFilter struct {
+	Status     string
+	Category   string
+	SourceName string
+}
+
+// List the alerts of a backup server
+func (s *AlertsOp) List(ctx context.Context, serverName string, filter *AlertFilter) ([]Alert, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if filter == nil {
+		filter = new(AlertFilter)
+	}
+
+	var alerts []Alert
+	query := selectFrom("ALERTS",
+		sqlName("STATUS", filter.Status),
+		sqlName("CATEGORY", filter.Category),
+		sqlName("SOURCE_NAME", filter.SourceName))
+	resp, err := s.client.CLI.Query(ctx, serverName, query, &alerts)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	for i := range alerts {
+		alerts[i].Server = serverName
+
+		// The message text starts with its number, such as ANR0102E
+		m := parseMessage(alerts[i].Message)
+		alerts[i].MessageNumber = m.Number
+		alerts[i].Severity = m.Severity
+	}
+
+	return alerts, resp, err
+}
+
+// ListAll lists the alerts of the servers selected by opts. The alerts of the servers that
+// could be queried are returned along with a *FanOutError for the others.
+func (s *AlertsOp) ListAll(ctx context.Context, opts *FanOutOptions, filter *AlertFilter) ([]Alert, error) {
+	results, err := s.client.FanOut(ctx, opts, func(ctx context.Context, serverName string) (interface{}, *http.Response, error) {
+		alerts, resp, err := s.List(ctx, serverName, filter)
+		return alerts, resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var alerts []Alert
+	for _, result := range results {
+		if serverAlerts, ok := result.Value.([]Alert); ok {
+			alerts = append(alerts, serverAlerts...)
+		}
+	}
+
+	return alerts, results.Err()
+}
+
+// Acknowledge an alert by making it inactive
+func (s *AlertsOp) Acknowledge(ctx context.Context, serverName string, alertID int64, remark string) (*http.Response, error) {
+	return s.updateStatus(ctx, serverName, alertID, AlertInactive, "", remark)
+}
+
+// Assign an alert to an administrator
+func (s *AlertsOp) Assign(ctx context.Context, serverName string, alertID int64, adminName string) (*http.Response, error) {
+	if adminName == "" {
+		return nil, NewArgError("adminName", "cannot be empty")
+	}
+
+	return s.updateStatus(ctx, serverName, alertID, "", adminName, "")
+}
+
+// Close an alert
+func (s *AlertsOp) Close(ctx context.Context, serverName string, alertID int64, remark string) (*http.Response, error) {
+	return s.updateStatus(ctx, serverName, alertID, AlertClosed, "", remark)
+}
+
+func (s *AlertsOp) updateStatus(ctx context.Context, serverName string, alertID int64, status string, adminName string, remark string) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if alertID <= 0 {
+		return nil, NewArgError("alertID", "must be positive")
+	}
+
+	cmd := newCommand("UPDATE", "ALERTSTATUS", strconv.FormatInt(alertID, 10)).
+		param("STATUS", status).
+		param("ASSIGNED", adminName).
+		param("REMARK", remark)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
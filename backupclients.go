@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 const clientsBasePath = "/oc/api/clients"
@@ -133,6 +134,7 @@ type BackupClients interface {
 	DecommissionVM(ctx context.Context, serverName string, clientName string, vmName string) (*http.Response, error)
 	Details(ctx context.Context, serverName string, clientName string) (*BackupClientDetail, *http.Response, error)
 	FileSpaces(ctx context.Context, serverName string, clientName string) ([]BackupClientFileSpace, *http.Response, error)
+	History(ctx context.Context, serverName string, clientName string, since time.Time) (ClientHistory, *http.Response, error)
 	List(ctx context.Context) ([]BackupClient, *http.Response, error)
 	Lock(ctx context.Context, serverName string, clientName string) (*http.Response, error)
 	RegisterNode(ctx context.Context, serverName string, createRequest *RegisterClientRequest) (*http.Response, error)
@@ -200,7 +202,7 @@ func (s *BackupClientsOp) Details(ctx context.Context, serverName string, client
 	}
 
 	if root.ClientDetail == nil {
-		return nil, resp, fmt.Errorf("Unable to find client %s on server %s", clientName, serverName)
+		return nil, resp, fmt.Errorf("Unable to find client %s on server %s: %w", clientName, serverName, ErrNotFound)
 	}
 
 	return root.ClientDetail, resp, err
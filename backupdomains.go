@@ -2,19 +2,29 @@ package gospoc
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 )
 
-const domainsBasePath = "/oc/api/domains"
+const (
+	domainsBasePath  = "/oc/api/domains"
+	defaultPolicySet = "STANDARD"
+)
 
 // BackupDomains is an interface for interacting with
 // IBM Spectrum Protect backup domains
 type BackupDomains interface {
-	List(ctx context.Context) ([]BackupServer, *http.Response, error)
-	Get(ctx context.Context, serverName string) (*BackupServer, *http.Response, error)
+	Create(ctx context.Context, serverName string, createRequest *BackupDomainRequest) (*http.Response, error)
+	Delete(ctx context.Context, serverName string, domainName string) (*http.Response, error)
+	Get(ctx context.Context, serverName string, domainName string) (*BackupDomain, *http.Response, error)
+	List(ctx context.Context) ([]BackupDomain, *http.Response, error)
+	ListByServer(ctx context.Context, serverName string) ([]BackupDomain, *http.Response, error)
+	Update(ctx context.Context, serverName string, domainName string, update *BackupDomainRequest) (*http.Response, error)
 }
 
-// BackupDomainsOp handles communication with the backup server related methods of the
+// BackupDomainsOp handles communication with the backup domain related methods of the
 // IBM Spectrum Protect Operations Center REST API
 type BackupDomainsOp struct {
 	client *Client
@@ -41,28 +51,69 @@ type BackupDomain struct {
 }
 
 type backupDomainsRoot struct {
-	Domains      []BackupDomains `json:"domains"`
-	DomainsCount int             `json:"domains_count"`
+	Domains      []BackupDomain `json:"domains"`
+	DomainsCount int            `json:"domains_count"`
 }
 
 type domainDetailRoot struct {
 	DomainDetail *BackupDomain `json:"domaindetail"`
 }
 
-// List all backup domains
-func (s *BackupDomainsOp) List(ctx context.Context) ([]BackupServer, *http.Response, error) {
+// BackupDomainRequest represents a request to define or update a backup domain.
+// Empty fields are left unchanged on update.
+type BackupDomainRequest struct {
+	Name        string
+	Description string
+
+	// Days to keep backup and archive data of clients no longer bound to a management class
+	BackupRetention  *int
+	ArchiveRetention *int
+
+	// DefaultMgmtClass is assigned as the default management class of PolicySet,
+	// which is then activated. PolicySet defaults to STANDARD.
+	DefaultMgmtClass string
+	PolicySet        string
+
+	// OptionSet is the client option set assigned to every client of the domain
+	// on update. A new domain has no clients, so Create rejects it.
+	OptionSet string
+}
+
+// List all backup domains on all servers
+func (s *BackupDomainsOp) List(ctx context.Context) ([]BackupDomain, *http.Response, error) {
 	req, err := s.client.NewRequest(ctx, http.MethodGet, domainsBasePath, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	root := new(backupServersRoot)
+	root := new(backupDomainsRoot)
 	resp, err := s.client.Do(ctx, req, root)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	return root.Servers, resp, err
+	return root.Domains, resp, err
+}
+
+// ListByServer lists the backup domains of a specific backup server
+func (s *BackupDomainsOp) ListByServer(ctx context.Context, serverName string) ([]BackupDomain, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	domains, resp, err := s.List(ctx)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var serverDomains []BackupDomain
+	for _, domain := range domains {
+		if strings.EqualFold(domain.Server, serverName) {
+			serverDomains = append(serverDomains, domain)
+		}
+	}
+
+	return serverDomains, resp, err
 }
 
 // Get the details of a specific backup domain
@@ -71,6 +122,10 @@ func (s *BackupDomainsOp) Get(ctx context.Context, serverName string, domainName
 		return nil, nil, NewArgError("serverName", "cannot be empty")
 	}
 
+	if domainName == "" {
+		return nil, nil, NewArgError("domainName", "cannot be empty")
+	}
+
 	path := serversBasePath + "/" + serverName + "/domains/" + domainName + "/details"
 
 	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
@@ -84,5 +139,136 @@ func (s *BackupDomainsOp) Get(ctx context.Context, serverName string, domainName
 		return nil, resp, err
 	}
 
+	if root.DomainDetail == nil {
+		return nil, resp, fmt.Errorf("Unable to find domain %s on server %s: %w", domainName, serverName, ErrNotFound)
+	}
+
 	return root.DomainDetail, resp, err
 }
+
+// Create defines a new backup domain. When a default management class is requested, the
+// policy set and management class are defined as well before the policy set is activated.
+func (s *BackupDomainsOp) Create(ctx context.Context, serverName string, createRequest *BackupDomainRequest) (*http.Response, error) {
+	if createRequest == nil {
+		return nil, NewArgError("createRequest", "cannot be nil")
+	}
+
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if createRequest.Name == "" {
+		return nil, NewArgError("createRequest.Name", "cannot be empty")
+	}
+
+	if createRequest.OptionSet != "" {
+		return nil, NewArgError("createRequest.OptionSet", "can only be assigned on update, once the domain has clients")
+	}
+
+	cmd := newCommand("DEFINE", "DOMAIN").arg(createRequest.Name).
+		param("DESCRIPTION", createRequest.Description).
+		paramInt("BACKRETENTION", createRequest.BackupRetention).
+		paramInt("ARCHRETENTION", createRequest.ArchiveRetention)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	if err != nil || createRequest.DefaultMgmtClass == "" {
+		return resp, err
+	}
+
+	policySet := createRequest.policySet()
+
+	cmd = newCommand("DEFINE", "POLICYSET").arg(createRequest.Name).arg(policySet)
+	if _, resp, err = s.client.runCommand(ctx, serverName, cmd); err != nil {
+		return resp, err
+	}
+
+	cmd = newCommand("DEFINE", "MGMTCLASS").arg(createRequest.Name).arg(policySet).arg(createRequest.DefaultMgmtClass)
+	if _, resp, err = s.client.runCommand(ctx, serverName, cmd); err != nil {
+		return resp, err
+	}
+
+	if resp, err = s.client.Policies.AssignDefaultMgmtClass(ctx, serverName, createRequest.Name, policySet, createRequest.DefaultMgmtClass); err != nil {
+		return resp, err
+	}
+
+	return s.client.Policies.ActivatePolicySet(ctx, serverName, createRequest.Name, policySet)
+}
+
+// Update the settings of a backup domain. When a default management class is requested,
+// it must already exist in the policy set, which is activated afterwards. When an option
+// set is requested, it is assigned to every client of the domain.
+func (s *BackupDomainsOp) Update(ctx context.Context, serverName string, domainName string, update *BackupDomainRequest) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if domainName == "" {
+		return nil, NewArgError("domainName", "cannot be empty")
+	}
+
+	if update == nil {
+		return nil, NewArgError("update", "cannot be nil")
+	}
+
+	var resp *http.Response
+	var err error
+
+	if update.Description != "" || update.BackupRetention != nil || update.ArchiveRetention != nil {
+		cmd := newCommand("UPDATE", "DOMAIN").arg(domainName).
+			param("DESCRIPTION", update.Description).
+			paramInt("BACKRETENTION", update.BackupRetention).
+			paramInt("ARCHRETENTION", update.ArchiveRetention)
+
+		if _, resp, err = s.client.runCommand(ctx, serverName, cmd); err != nil {
+			return resp, err
+		}
+	}
+
+	if update.DefaultMgmtClass != "" {
+		policySet := update.policySet()
+		if resp, err = s.client.Policies.AssignDefaultMgmtClass(ctx, serverName, domainName, policySet, update.DefaultMgmtClass); err != nil {
+			return resp, err
+		}
+
+		if resp, err = s.client.Policies.ActivatePolicySet(ctx, serverName, domainName, policySet); err != nil {
+			return resp, err
+		}
+	}
+
+	if update.OptionSet != "" {
+		cmd := newCommand("UPDATE", "NODE").arg("*").
+			param("CLOPTSET", update.OptionSet).
+			param("WHEREDOMAIN", domainName)
+
+		// A domain without clients has no nodes to update
+		if _, resp, err = s.client.runCommand(ctx, serverName, cmd); err != nil && !errors.Is(err, ErrNotFound) {
+			return resp, err
+		}
+		err = nil
+	}
+
+	return resp, err
+}
+
+// Delete a backup domain
+func (s *BackupDomainsOp) Delete(ctx context.Context, serverName string, domainName string) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if domainName == "" {
+		return nil, NewArgError("domainName", "cannot be empty")
+	}
+
+	cmd := newCommand("DELETE", "DOMAIN").arg(domainName)
+
+	_, resp, err := s.client.runConfirmedCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+func (r *BackupDomainRequest) policySet() string {
+	if r.PolicySet == "" {
+		return defaultPolicySet
+	}
+	return r.PolicySet
+}
@@ -0,0 +1,271 @@
+package gospoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+var urlSchemes = []string{"7.1.4", "8.1.0"}
+
+// setup starts a test Operations Center and returns a client for it using urlScheme
+func setup(t *testing.T, urlScheme string) (*Client, *http.ServeMux, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client, err := NewClientWithOptions(&Config{Username: "admin", Password: "secret", URLScheme: urlScheme},
+		WithBaseURL(server.URL), WithRetryPolicy(nil))
+	if err != nil {
+		server.Close()
+		t.Fatalf("NewClientWithOptions returned error: %v", err)
+	}
+
+	return client, mux, server.Close
+}
+
+// handleCommands records the commands issued through the CLI endpoints
+func handleCommands(t *testing.T, mux *http.ServeMux, serverName string) *[]string {
+	var commands []string
+	handler := func(confirmed bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				t.Errorf("Request method = %v, expected POST", r.Method)
+			}
+			body, _ := ioutil.ReadAll(r.Body)
+			command := string(body)
+			if confirmed {
+				command = "CONFIRMED " + command
+			}
+			commands = append(commands, command)
+			fmt.Fprint(w, `{"MESSAGES":["ANR2017I Administrator ADMIN issued command"],"RC":0}`)
+		}
+	}
+	mux.HandleFunc(cliBasePath+"/issueCommand/"+serverName, handler(false))
+	mux.HandleFunc(cliBasePath+"/issueConfirmedCommand/"+serverName, handler(true))
+	return &commands
+}
+
+const domainsJSON = `{"domains":[
+	{"NAME":"STANDARD","SERVER":"SERVER1","NUM_CLIENTS":3,"DEF_MC":"STANDARD"},
+	{"NAME":"VMWARE","SERVER":"SERVER2","NUM_CLIENTS":12,"DEF_MC":"VM30"}
+],"domains_count":2}`
+
+func TestBackupDomains_List(t *testing.T) {
+	for _, scheme := range urlSchemes {
+		t.Run(scheme, func(t *testing.T) {
+			client, mux, teardown := setup(t, scheme)
+			defer teardown()
+
+			mux.HandleFunc(domainsBasePath, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Errorf("Request method = %v, expected GET", r.Method)
+				}
+				fmt.Fprint(w, domainsJSON)
+			})
+
+			domains, _, err := client.Domains.List(context.Background())
+			if err != nil {
+				t.Errorf("Domains.List returned error: %v", err)
+			}
+
+			expected := []BackupDomain{
+				{Name: "STANDARD", Server: "SERVER1", NumClients: 3, DefMC: "STANDARD"},
+				{Name: "VMWARE", Server: "SERVER2", NumClients: 12, DefMC: "VM30"},
+			}
+			if !reflect.DeepEqual(domains, expected) {
+				t.Errorf("Domains.List returned %+v, expected %+v", domains, expected)
+			}
+		})
+	}
+}
+
+func TestBackupDomains_ListByServer(t *testing.T) {
+	for _, scheme := range urlSchemes {
+		t.Run(scheme, func(t *testing.T) {
+			client, mux, teardown := setup(t, scheme)
+			defer teardown()
+
+			mux.HandleFunc(domainsBasePath, func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, domainsJSON)
+			})
+
+			domains, _, err := client.Domains.ListByServer(context.Background(), "server2")
+			if err != nil {
+				t.Errorf("Domains.ListByServer returned error: %v", err)
+			}
+
+			if len(domains) != 1 || domains[0].Name != "VMWARE" {
+				t.Errorf("Domains.ListByServer returned %+v, expected only VMWARE", domains)
+			}
+
+			if _, _, err := client.Domains.ListByServer(context.Background(), ""); err == nil {
+				t.Errorf("Domains.ListByServer with an empty server name did not return an error")
+			}
+		})
+	}
+}
+
+func TestBackupDomains_Get(t *testing.T) {
+	for _, scheme := range urlSchemes {
+		t.Run(scheme, func(t *testing.T) {
+			client, mux, teardown := setup(t, scheme)
+			defer teardown()
+
+			mux.HandleFunc(serversBasePath+"/SERVER1/domains/STANDARD/details", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Errorf("Request method = %v, expected GET", r.Method)
+				}
+				fmt.Fprint(w, `{"domaindetail":{"NAME":"STANDARD","SERVER":"SERVER1","MGMTCLASS_COUNT":2}}`)
+			})
+
+			domain, _, err := client.Domains.Get(context.Background(), "SERVER1", "STANDARD")
+			if err != nil {
+				t.Errorf("Domains.Get returned error: %v", err)
+			}
+
+			expected := &BackupDomain{Name: "STANDARD", Server: "SERVER1", MgmtClassCount: 2}
+			if !reflect.DeepEqual(domain, expected) {
+				t.Errorf("Domains.Get returned %+v, expected %+v", domain, expected)
+			}
+
+			_, _, err = client.Domains.Get(context.Background(), "SERVER1", "MISSING")
+			if !errors.Is(err, ErrNotFound) {
+				t.Errorf("Domains.Get of a missing domain returned %v, expected ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestBackupDomains_Create(t *testing.T) {
+	for _, scheme := range urlSchemes {
+		t.Run(scheme, func(t *testing.T) {
+			client, mux, teardown := setup(t, scheme)
+			defer teardown()
+			commands := handleCommands(t, mux, "SERVER1")
+
+			retention := 30
+			_, err := client.Domains.Create(context.Background(), "SERVER1", &BackupDomainRequest{
+				Name:             "FINANCE",
+				Description:      "Finance department",
+				BackupRetention:  &retention,
+				DefaultMgmtClass: "DEFAULT",
+			})
+			if err != nil {
+				t.Errorf("Domains.Create returned error: %v", err)
+			}
+
+			expected := []string{
+				`DEFINE DOMAIN FINANCE DESCRIPTION="Finance department" BACKRETENTION=30`,
+				"DEFINE POLICYSET FINANCE STANDARD",
+				"DEFINE MGMTCLASS FINANCE STANDARD DEFAULT",
+				"ASSIGN DEFMGMTCLASS FINANCE STANDARD DEFAULT",
+				"CONFIRMED ACTIVATE POLICYSET FINANCE STANDARD",
+			}
+			if !reflect.DeepEqual(*commands, expected) {
+				t.Errorf("Domains.Create issued %q, expected %q", *commands, expected)
+			}
+
+			if _, err := client.Domains.Create(context.Background(), "SERVER1", &BackupDomainRequest{}); err == nil {
+				t.Errorf("Domains.Create without a name did not return an error")
+			}
+
+			if _, err := client.Domains.Create(context.Background(), "SERVER1", &BackupDomainRequest{Name: "HR", OptionSet: "WINDOWS"}); err == nil {
+				t.Errorf("Domains.Create with an option set did not return an error")
+			}
+		})
+	}
+}
+
+func TestBackupDomains_Update(t *testing.T) {
+	for _, scheme := range urlSchemes {
+		t.Run(scheme, func(t *testing.T) {
+			client, mux, teardown := setup(t, scheme)
+			defer teardown()
+			commands := handleCommands(t, mux, "SERVER1")
+
+			_, err := client.Domains.Update(context.Background(), "SERVER1", "FINANCE", &BackupDomainRequest{
+				Description:      "Finance",
+				DefaultMgmtClass: "LONG",
+				PolicySet:        "ACTIVE",
+			})
+			if err != nil {
+				t.Errorf("Domains.Update returned error: %v", err)
+			}
+
+			expected := []string{
+				"UPDATE DOMAIN FINANCE DESCRIPTION=Finance",
+				"ASSIGN DEFMGMTCLASS FINANCE ACTIVE LONG",
+				"CONFIRMED ACTIVATE POLICYSET FINANCE ACTIVE",
+			}
+			if !reflect.DeepEqual(*commands, expected) {
+				t.Errorf("Domains.Update issued %q, expected %q", *commands, expected)
+			}
+		})
+	}
+}
+
+func TestBackupDomains_Delete(t *testing.T) {
+	for _, scheme := range urlSchemes {
+		t.Run(scheme, func(t *testing.T) {
+			client, mux, teardown := setup(t, scheme)
+			defer teardown()
+			commands := handleCommands(t, mux, "SERVER1")
+
+			if _, err := client.Domains.Delete(context.Background(), "SERVER1", "FINANCE"); err != nil {
+				t.Errorf("Domains.Delete returned error: %v", err)
+			}
+
+			expected := []string{"CONFIRMED DELETE DOMAIN FINANCE"}
+			if !reflect.DeepEqual(*commands, expected) {
+				t.Errorf("Domains.Delete issued %q, expected %q", *commands, expected)
+			}
+		})
+	}
+}
+
+func TestBackupDomains_CommandError(t *testing.T) {
+	for _, scheme := range urlSchemes {
+		t.Run(scheme, func(t *testing.T) {
+			client, mux, teardown := setup(t, scheme)
+			defer teardown()
+
+			mux.HandleFunc(cliBasePath+"/issueConfirmedCommand/SERVER1", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"MESSAGES":["ANR1501E DELETE DOMAIN: Policy domain MISSING is not defined."],"RC":11}`)
+			})
+
+			_, err := client.Domains.Delete(context.Background(), "SERVER1", "MISSING")
+			if !errors.Is(err, ErrNotFound) {
+				t.Errorf("Domains.Delete of a missing domain returned %v, expected ErrNotFound", err)
+			}
+
+			var cmdErr *CommandError
+			if !errors.As(err, &cmdErr) || cmdErr.MessageNumber != "ANR1501E" {
+				t.Errorf("Domains.Delete returned %#v, expected a CommandError for ANR1501E", err)
+			}
+		})
+	}
+}
+
+func TestBackupDomains_UpdateOptionSet(t *testing.T) {
+	for _, scheme := range urlSchemes {
+		t.Run(scheme, func(t *testing.T) {
+			client, mux, teardown := setup(t, scheme)
+			defer teardown()
+			commands := handleCommands(t, mux, "SERVER1")
+
+			if _, err := client.Domains.Update(context.Background(), "SERVER1", "FINANCE", &BackupDomainRequest{OptionSet: "WINDOWS"}); err != nil {
+				t.Errorf("Domains.Update returned error: %v", err)
+			}
+
+			expected := []string{"UPDATE NODE * CLOPTSET=WINDOWS WHEREDOMAIN=FINANCE"}
+			if !reflect.DeepEqual(*commands, expected) {
+				t.Errorf("Domains.Update issued %q, expected %q", *commands, expected)
+			}
+		})
+	}
+}
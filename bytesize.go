@@ -0,0 +1,65 @@
+package gospoc
+
+import (
+	"fmt"
+	"math"
+)
+
+// ByteSize is a quantity of bytes
+type ByteSize int64
+
+// Byte quantities. IBM Spectrum Protect reports megabytes of 1024*1024 bytes.
+const (
+	Byte ByteSize = 1
+	KiB           = 1024 * Byte
+	MiB           = 1024 * KiB
+	GiB           = 1024 * MiB
+	TiB           = 1024 * GiB
+	PiB           = 1024 * TiB
+)
+
+// byteSizeFromMB converts the megabytes reported by the server
+func byteSizeFromMB(mb float64) ByteSize {
+	return ByteSize(math.Round(mb * float64(MiB)))
+}
+
+// MB returns the size in megabytes
+func (b ByteSize) MB() float64 {
+	return float64(b) / float64(MiB)
+}
+
+// GB returns the size in gigabytes
+func (b ByteSize) GB() float64 {
+	return float64(b) / float64(GiB)
+}
+
+// TB returns the size in terabytes
+func (b ByteSize) TB() float64 {
+	return float64(b) / float64(TiB)
+}
+
+func (b ByteSize) String() string {
+	units := []struct {
+		size ByteSize
+		name string
+	}{
+		{PiB, "PiB"},
+		{TiB, "TiB"},
+		{GiB, "GiB"},
+		{MiB, "MiB"},
+		{KiB, "KiB"},
+	}
+
+	abs := b
+	if abs < 0 {
+		abs = -abs
+	}
+
+	for _, u := range units {
+		if abs >= u.size {
+			return fmt.Sprintf("%.2f %s", float64(b)/float64(u.size), u.name)
+		}
+	}
+
+	return fmt.Sprintf("%d B", int64(b))
+}
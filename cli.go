@@ -1,8 +1,12 @@
 package gospoc
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"strings"
 )
 
 const (
@@ -13,8 +17,9 @@ const (
 // CLI is an interface for interacting with
 // IBM Spectrum Protect CLI
 type CLI interface {
-	IssueCommand(ctx context.Context, serverName string, command string) (*http.Response, error)
-	IssueConfirmCommand(ctx context.Context, serverName string, command string) (*http.Response, error)
+	IssueCommand(ctx context.Context, serverName string, command string) (*CommandResult, *http.Response, error)
+	IssueConfirmCommand(ctx context.Context, serverName string, command string) (*CommandResult, *http.Response, error)
+	Query(ctx context.Context, serverName string, query string, dest interface{}) (*http.Response, error)
 }
 
 // CLIOp handles communication with the cli related methods of the
@@ -23,46 +28,84 @@ type CLIOp struct {
 	client *Client
 }
 
-// IssueCommand issues a TSM Command
-func (s *CLIOp) IssueCommand(ctx context.Context, serverName string, command string) (*http.Response, error) {
-	path := cliBasePath + "/issueCommand"
+// IssueCommand issues a TSM Command. A command that completes with a non-zero
+// return code is returned along with a *CommandError.
+func (s *CLIOp) IssueCommand(ctx context.Context, serverName string, command string) (*CommandResult, *http.Response, error) {
+	return s.issue(ctx, cliBasePath+"/issueCommand", serverName, command)
+}
+
+// IssueConfirmCommand issues a confirmed TSM Command. A command that completes with
+// a non-zero return code is returned along with a *CommandError.
+func (s *CLIOp) IssueConfirmCommand(ctx context.Context, serverName string, command string) (*CommandResult, *http.Response, error) {
+	return s.issue(ctx, cliBasePath+"/issueConfirmedCommand", serverName, command)
+}
+
+func (s *CLIOp) issue(ctx context.Context, path string, serverName string, command string) (*CommandResult, *http.Response, error) {
+	if command == "" {
+		return nil, nil, NewArgError("command", "cannot be empty")
+	}
 
 	if serverName != "" {
 		path = path + "/" + serverName
 	}
 
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, command)
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	req.Header.Set("Content-Type", cliContentType)
 
-	resp, err := s.client.Do(ctx, req, nil)
-	if err != nil {
-		return resp, err
+	// The command is sent as plain text rather than JSON encoded. A command holding
+	// a secret cannot be read back from the request once sent, so it is not retried.
+	req.ContentLength = int64(len(command))
+	if hasSecretBody(ctx) {
+		req.Body = &secretBody{r: strings.NewReader(command)}
+	} else {
+		req.Body = ioutil.NopCloser(strings.NewReader(command))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(strings.NewReader(command)), nil
+		}
 	}
 
-	return resp, err
-}
+	buf := new(bytes.Buffer)
+	resp, err := s.client.Do(ctx, req, buf)
+	if err != nil {
+		return nil, resp, err
+	}
 
-// IssueConfirmCommand issues a confirmed TSM Command
-func (s *CLIOp) IssueConfirmCommand(ctx context.Context, serverName string, command string) (*http.Response, error) {
-	path := cliBasePath + "/issueConfirmedCommand"
+	result, err := parseCommandResult(buf.Bytes())
+	if err != nil {
+		return nil, resp, err
+	}
+	result.Server = serverName
+	result.Command = command
 
-	if serverName != "" {
-		path = path + "/" + serverName
+	if result.ReturnCode != rcOK {
+		return result, resp, newCommandError(result)
 	}
 
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, command)
-	if err != nil {
-		return nil, err
+	return result, resp, nil
+}
+
+// secretBody is a request body that drops its content once read or closed, so
+// that the request of a response does not reveal it
+type secretBody struct {
+	r io.Reader
+}
+
+func (b *secretBody) Read(p []byte) (int, error) {
+	if b.r == nil {
+		return 0, io.EOF
 	}
-	req.Header.Set("Content-Type", cliContentType)
 
-	resp, err := s.client.Do(ctx, req, nil)
-	if err != nil {
-		return resp, err
+	n, err := b.r.Read(p)
+	if err == io.EOF {
+		b.r = nil
 	}
+	return n, err
+}
 
-	return resp, err
+func (b *secretBody) Close() error {
+	b.r = nil
+	return nil
 }
@@ -0,0 +1,157 @@
+package gospoc
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// ClientActivity is a summary record of a client session or process
+type ClientActivity struct {
+	Server    string    `spoc:"-"`
+	StartTime time.Time `spoc:"START_TIME,nullzero"`
+	EndTime   time.Time `spoc:"END_TIME,nullzero"`
+
+	// Activity is BACKUP, RESTORE, ARCHIVE, RETRIEVE and so on
+	Activity     string `spoc:"ACTIVITY,nullzero"`
+	ActivityType string `spoc:"ACTIVITY_TYPE,nullzero"`
+	Number       int64  `spoc:"NUMBER,nullzero"`
+	Client       string `spoc:"ENTITY,nullzero"`
+	Schedule     string `spoc:"SCHEDULE_NAME,nullzero"`
+
+	// Examined, Affected and Failed count the objects inspected, transferred and failed
+	Examined   int64 `spoc:"EXAMINED,nullzero"`
+	Affected   int64 `spoc:"AFFECTED,nullzero"`
+	Failed     int64 `spoc:"FAILED,nullzero"`
+	Expired    int64 `spoc:"EXPIRED,nullzero"`
+	Successful bool  `spoc:"SUCCESSFUL,nullzero"`
+
+	Bytes          ByteSize `spoc:"BYTES,nullzero"`
+	BytesProtected ByteSize `spoc:"BYTES_PROTECTED,nullzero"`
+	BytesWritten   ByteSize `spoc:"BYTES_WRITTEN,nullzero"`
+	DedupSavings   ByteSize `spoc:"DEDUP_SAVINGS,nullzero"`
+	CompSavings    ByteSize `spoc:"COMP_SAVINGS,nullzero"`
+}
+
+// Elapsed returns the duration of the activity
+func (a *ClientActivity) Elapsed() time.Duration {
+	if a.StartTime.IsZero() || a.EndTime.IsZero() {
+		return 0
+	}
+	return a.EndTime.Sub(a.StartTime)
+}
+
+// ClientHistory is the activity of a backup client, oldest first
+type ClientHistory []ClientActivity
+
+// ActivityTotal is the sum of a group of client activities. The fields
+// that are not grouped by are empty.
+type ActivityTotal struct {
+	// Day is midnight of the day the activities started, in the local time zone
+	Day      time.Time
+	Activity string
+
+	Count        int
+	Failures     int
+	Examined     int64
+	Affected     int64
+	Failed       int64
+	Expired      int64
+	Bytes        ByteSize
+	DedupSavings ByteSize
+	CompSavings  ByteSize
+	Elapsed      time.Duration
+}
+
+// ByDay totals the activities per day
+func (h ClientHistory) ByDay() []ActivityTotal {
+	return h.totals(func(a *ClientActivity) ActivityTotal {
+		start := a.StartTime.In(time.Local)
+		return ActivityTotal{Day: time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.Local)}
+	})
+}
+
+// ByActivity totals the activities per activity, such as BACKUP or RESTORE
+func (h ClientHistory) ByActivity() []ActivityTotal {
+	return h.totals(func(a *ClientActivity) ActivityTotal {
+		return ActivityTotal{Activity: a.Activity}
+	})
+}
+
+// ByDayAndActivity totals the activities per day and activity
+func (h ClientHistory) ByDayAndActivity() []ActivityTotal {
+	return h.totals(func(a *ClientActivity) ActivityTotal {
+		start := a.StartTime.In(time.Local)
+		return ActivityTotal{
+			Day:      time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.Local),
+			Activity: a.Activity,
+		}
+	})
+}
+
+func (h ClientHistory) totals(group func(*ClientActivity) ActivityTotal) []ActivityTotal {
+	var totals []ActivityTotal
+	index := make(map[ActivityTotal]int)
+
+	for i := range h {
+		a := &h[i]
+		key := group(a)
+		n, ok := index[key]
+		if !ok {
+			n = len(totals)
+			index[key] = n
+			totals = append(totals, key)
+		}
+
+		t := &totals[n]
+		t.Count++
+		if !a.Successful {
+			t.Failures++
+		}
+		t.Examined += a.Examined
+		t.Affected += a.Affected
+		t.Failed += a.Failed
+		t.Expired += a.Expired
+		t.Bytes += a.Bytes
+		t.DedupSavings += a.DedupSavings
+		t.CompSavings += a.CompSavings
+		t.Elapsed += a.Elapsed()
+	}
+
+	sort.SliceStable(totals, func(i, j int) bool {
+		if !totals[i].Day.Equal(totals[j].Day) {
+			return totals[i].Day.Before(totals[j].Day)
+		}
+		return totals[i].Activity < totals[j].Activity
+	})
+
+	return totals
+}
+
+// History returns the activity of a backup client since a point in time, from the
+// summary records of the server. A zero since returns every record the server retains.
+func (s *BackupClientsOp) History(ctx context.Context, serverName string, clientName string, since time.Time) (ClientHistory, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if clientName == "" {
+		return nil, nil, NewArgError("clientName", "cannot be empty")
+	}
+
+	var history ClientHistory
+	query := selectFrom("SUMMARY", sqlName("ENTITY", clientName), sqlTime("START_TIME", ">=", since))
+	resp, err := s.client.CLI.Query(ctx, serverName, query, &history)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	for i := range history {
+		history[i].Server = serverName
+	}
+
+	sort.SliceStable(history, func(i, j int) bool { return history[i].StartTime.Before(history[j].StartTime) })
+
+	return history, resp, err
+}
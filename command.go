@@ -0,0 +1,237 @@
+package gospoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// redacted replaces secret values, such as passwords, in commands and messages
+const redacted = "********"
+
+// command builds an administrative command, quoting the values that need it
+type command struct {
+	parts []string
+	err   error
+
+	// secrets are kept out of the command text reported in results and errors
+	secrets []string
+}
+
+func newCommand(words ...string) *command {
+	return &command{parts: words}
+}
+
+// arg appends a positional value
+func (c *command) arg(value string) *command {
+	c.parts = append(c.parts, c.quote(value))
+	return c
+}
+
+// secret appends a positional value that is redacted from results and errors
+func (c *command) secret(value string) *command {
+	c.parts = append(c.parts, c.quoteSecret(value))
+	return c
+}
+
+// argList appends a comma separated list of positional values
+func (c *command) argList(values []string) *command {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = c.quote(v)
+	}
+	c.parts = append(c.parts, strings.Join(quoted, ","))
+	return c
+}
+
+// param appends NAME=value, unless value is empty
+func (c *command) param(name string, value string) *command {
+	if value != "" {
+		c.parts = append(c.parts, name+"="+c.quote(value))
+	}
+	return c
+}
+
+// paramSecret appends NAME=value, unless value is empty, redacting value from results and errors
+func (c *command) paramSecret(name string, value string) *command {
+	if value != "" {
+		c.parts = append(c.parts, name+"="+c.quoteSecret(value))
+	}
+	return c
+}
+
+// paramInt appends NAME=value, unless value is nil
+func (c *command) paramInt(name string, value *int) *command {
+	if value != nil {
+		c.parts = append(c.parts, name+"="+strconv.Itoa(*value))
+	}
+	return c
+}
+
+// paramBool appends NAME=YES or NAME=NO, unless value is nil
+func (c *command) paramBool(name string, value *bool) *command {
+	if value != nil {
+		c.parts = append(c.parts, name+"="+yesNo(*value))
+	}
+	return c
+}
+
+// paramList appends NAME=a,b,c, unless values is empty
+func (c *command) paramList(name string, values []string) *command {
+	if len(values) > 0 {
+		quoted := make([]string, len(values))
+		for i, v := range values {
+			quoted[i] = c.quote(v)
+		}
+		c.parts = append(c.parts, name+"="+strings.Join(quoted, ","))
+	}
+	return c
+}
+
+// build returns the command text, or the first quoting error
+func (c *command) build() (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+	return strings.Join(c.parts, " "), nil
+}
+
+// quote wraps value in quotes when it contains blanks or characters with a
+// meaning to the command parser
+func (c *command) quote(value string) string {
+	if value == "" {
+		return `""`
+	}
+
+	// Line breaks would end the command, whether quoted or not
+	if strings.ContainsAny(value, "\r\n") || (strings.Contains(value, `"`) && strings.Contains(value, "'")) {
+		if c.err == nil {
+			c.err = fmt.Errorf("Unable to quote %q in an administrative command", value)
+		}
+		return value
+	}
+
+	if !strings.ContainsAny(value, " \t,='\"") {
+		return value
+	}
+
+	if strings.Contains(value, `"`) {
+		return "'" + value + "'"
+	}
+	return `"` + value + `"`
+}
+
+// quoteSecret quotes a secret value without revealing it in the quoting error
+func (c *command) quoteSecret(value string) string {
+	if value == "" {
+		return c.quote(value)
+	}
+
+	c.secrets = append(c.secrets, value)
+
+	failed := c.err != nil
+	quoted := c.quote(value)
+	if !failed && c.err != nil {
+		c.err = errors.New("Unable to quote a secret value in an administrative command")
+	}
+	return quoted
+}
+
+// redact replaces the secret values of the command in s
+func (c *command) redact(s string) string {
+	for _, secret := range c.secrets {
+		s = strings.Replace(s, secret, redacted, -1)
+	}
+	return s
+}
+
+// redactResult removes the secret values of the command from the result and error of issuing it
+func (c *command) redactResult(result *CommandResult, err error) {
+	if len(c.secrets) == 0 {
+		return
+	}
+
+	if result != nil {
+		result.Command = c.redact(result.Command)
+		c.redactMessages(result.Messages)
+	}
+
+	var cmdErr *CommandError
+	if errors.As(err, &cmdErr) {
+		cmdErr.Command = c.redact(cmdErr.Command)
+		c.redactMessages(cmdErr.Messages)
+	}
+
+	// The Operations Center may echo the command in the body of a failed request
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) {
+		errResp.Message = c.redact(errResp.Message)
+	}
+}
+
+type secretBodyKey struct{}
+
+// context marks the request issuing the command as carrying secrets, so that
+// CLI requests keep its body from the request of the response
+func (c *command) context(ctx context.Context) context.Context {
+	if len(c.secrets) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, secretBodyKey{}, true)
+}
+
+func hasSecretBody(ctx context.Context) bool {
+	v, ok := ctx.Value(secretBodyKey{}).(bool)
+	return ok && v
+}
+
+func (c *command) redactMessages(messages []CommandMessage) {
+	for i := range messages {
+		messages[i].Text = c.redact(messages[i].Text)
+	}
+}
+
+// splitList splits a comma separated list such as MONDAY,FRIDAY into its values
+func splitList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "YES"
+	}
+	return "NO"
+}
+
+// runCommand issues cmd on serverName
+func (c *Client) runCommand(ctx context.Context, serverName string, cmd *command) (*CommandResult, *http.Response, error) {
+	text, err := cmd.build()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, resp, err := c.CLI.IssueCommand(cmd.context(ctx), serverName, text)
+	cmd.redactResult(result, err)
+	return result, resp, err
+}
+
+// runConfirmedCommand issues cmd on serverName for commands that ask for confirmation
+func (c *Client) runConfirmedCommand(ctx context.Context, serverName string, cmd *command) (*CommandResult, *http.Response, error) {
+	text, err := cmd.build()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, resp, err := c.CLI.IssueConfirmCommand(cmd.context(ctx), serverName, text)
+	cmd.redactResult(result, err)
+	return result, resp, err
+}
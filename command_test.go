@@ -0,0 +1,113 @@
+package gospoc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommand_Quote(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"NODE1", "NODE1"},
+		{"", `""`},
+		{"Finance department", `"Finance department"`},
+		{"a\tb", "\"a\tb\""},
+		{"MONDAY,FRIDAY", `"MONDAY,FRIDAY"`},
+		{"-subdir=yes", `"-subdir=yes"`},
+		{"O'Brien", `"O'Brien"`},
+		{`say "hi"`, `'say "hi"'`},
+		{"/home/*", "/home/*"},
+	}
+
+	for _, tt := range tests {
+		cmd := newCommand()
+		if got := cmd.quote(tt.value); got != tt.expected {
+			t.Errorf("quote(%q) returned %s, expected %s", tt.value, got, tt.expected)
+		}
+		if cmd.err != nil {
+			t.Errorf("quote(%q) failed with %v", tt.value, cmd.err)
+		}
+	}
+}
+
+func TestCommand_QuoteErrors(t *testing.T) {
+	for _, value := range []string{`it's "quoted"`, "two\nlines", "carriage\rreturn"} {
+		_, err := newCommand("UPDATE", "NODE").arg("NODE1").param("CONTACT", value).build()
+		if err == nil {
+			t.Errorf("build with CONTACT=%q did not return an error", value)
+		}
+	}
+
+	_, err := newCommand("REGISTER", "ADMIN").arg("JDOE").secret(`Pa55'"word`).build()
+	if err == nil {
+		t.Fatal("build with an unquotable secret did not return an error")
+	}
+	if strings.Contains(err.Error(), "Pa55") {
+		t.Errorf("build returned %q, which contains the secret", err)
+	}
+}
+
+func TestCommand_Build(t *testing.T) {
+	five := 5
+	yes := true
+
+	tests := []struct {
+		cmd      *command
+		expected string
+	}{
+		{
+			newCommand("DEFINE", "SCHEDULE").arg("STANDARD").arg("NIGHTLY").
+				param("DESCRIPTION", "Nightly, incremental").
+				param("OBJECTS", "").
+				paramInt("DURATION", &five).
+				paramBool("ACTIVE", &yes).
+				paramList("DAYOFWEEK", splitList("MONDAY, FRIDAY")),
+			`DEFINE SCHEDULE STANDARD NIGHTLY DESCRIPTION="Nightly, incremental" DURATION=5 ACTIVE=YES DAYOFWEEK=MONDAY,FRIDAY`,
+		},
+		{
+			newCommand("CHECKIN", "LIBVOLUME").arg("LIB1").
+				paramList("VOLLIST", []string{"A00001", "B,00002", "C 00003"}).
+				paramList("EMPTY", nil),
+			`CHECKIN LIBVOLUME LIB1 VOLLIST=A00001,"B,00002","C 00003"`,
+		},
+		{
+			newCommand("REPLICATE", "NODE").argList([]string{"NODE1", "NODE,2"}),
+			`REPLICATE NODE NODE1,"NODE,2"`,
+		},
+	}
+
+	for _, tt := range tests {
+		text, err := tt.cmd.build()
+		if err != nil {
+			t.Errorf("build returned error: %v", err)
+			continue
+		}
+		if text != tt.expected {
+			t.Errorf("build returned %s, expected %s", text, tt.expected)
+		}
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	if got := splitList(" MONDAY, ,FRIDAY "); len(got) != 2 || got[0] != "MONDAY" || got[1] != "FRIDAY" {
+		t.Errorf("splitList returned %q, expected [MONDAY FRIDAY]", got)
+	}
+	if got := splitList(""); got != nil {
+		t.Errorf("splitList of an empty list returned %q, expected nil", got)
+	}
+}
+
+func TestCommand_Redact(t *testing.T) {
+	cmd := newCommand("UPDATE", "ADMIN").arg("JDOE").secret("Pa55word")
+	result := &CommandResult{
+		Command:  "UPDATE ADMIN JDOE Pa55word",
+		Messages: []CommandMessage{{Number: "ANR2017I", Text: "Administrator ADMIN issued command: UPDATE ADMIN JDOE Pa55word"}},
+	}
+	cmd.redactResult(result, nil)
+
+	if strings.Contains(result.Command, "Pa55word") || strings.Contains(result.Messages[0].Text, "Pa55word") {
+		t.Errorf("redactResult left the secret in %+v", result)
+	}
+}
@@ -0,0 +1,412 @@
+package gospoc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MessageSeverity is the severity code that ends an IBM Spectrum Protect message number
+type MessageSeverity string
+
+// Message severities
+const (
+	SeverityInformation MessageSeverity = "I"
+	SeverityWarning     MessageSeverity = "W"
+	SeverityError       MessageSeverity = "E"
+	SeveritySevere      MessageSeverity = "S"
+	SeverityKernel      MessageSeverity = "K"
+	SeverityDiagnostic  MessageSeverity = "D"
+)
+
+// CommandMessage is an ANR or ANS message returned by an administrative command
+type CommandMessage struct {
+	Number   string
+	Severity MessageSeverity
+	Text     string
+}
+
+func (m CommandMessage) String() string {
+	if m.Number == "" {
+		return m.Text
+	}
+	return m.Number + " " + m.Text
+}
+
+// IsError reports whether the message has error or severe severity
+func (m CommandMessage) IsError() bool {
+	return m.Severity == SeverityError || m.Severity == SeveritySevere || m.Severity == SeverityKernel
+}
+
+// CommandRow is one row of the tabular output of a command, keyed by column name.
+// Columns whose value is NULL are absent from the row.
+type CommandRow map[string]string
+
+// CommandResult contains the parsed output of an administrative command
+type CommandResult struct {
+	Server     string
+	Command    string
+	ReturnCode int
+	Messages   []CommandMessage
+	Columns    []string
+	Rows       []CommandRow
+}
+
+// ErrorMessages returns the messages with error or severe severity
+func (r *CommandResult) ErrorMessages() []CommandMessage {
+	var errs []CommandMessage
+	for _, m := range r.Messages {
+		if m.IsError() {
+			errs = append(errs, m)
+		}
+	}
+	return errs
+}
+
+// Message returns the first message with the given number, such as ANR0984I
+func (r *CommandResult) Message(number string) (CommandMessage, bool) {
+	for _, m := range r.Messages {
+		if m.Number == number {
+			return m, true
+		}
+	}
+	return CommandMessage{}, false
+}
+
+var messageLineRe = regexp.MustCompile(`^\s*(AN[RSE][0-9]{4}([IEWSKD]))\s+(.*)$`)
+
+// parseMessage splits a message line into its number, severity and text
+func parseMessage(line string) CommandMessage {
+	m := messageLineRe.FindStringSubmatch(line)
+	if m == nil {
+		return CommandMessage{Text: strings.TrimSpace(line)}
+	}
+	return CommandMessage{Number: m[1], Severity: MessageSeverity(m[2]), Text: strings.TrimSpace(m[3])}
+}
+
+// parseCommandResult parses the response body of the issueCommand endpoints.
+// JSON bodies are expected to hold the messages, items and return code of the
+// command. Anything else is parsed as the text output of the administrative
+// command line client.
+func parseCommandResult(data []byte) (*CommandResult, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		return parseCommandJSON(trimmed)
+	}
+
+	return parseCommandText(string(data)), nil
+}
+
+func parseCommandJSON(data []byte) (*CommandResult, error) {
+	result := new(CommandResult)
+	returnCode := -1
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+
+		switch strings.ToUpper(key) {
+		case "RC", "RETURNCODE", "RETURN_CODE":
+			var n json.Number
+			if err := dec.Decode(&n); err != nil {
+				return nil, err
+			}
+			rc, err := strconv.Atoi(n.String())
+			if err != nil {
+				return nil, err
+			}
+			returnCode = rc
+		case "MESSAGES", "MESSAGE":
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return nil, err
+			}
+			result.Messages = append(result.Messages, parseJSONMessages(raw)...)
+		case "ITEMS", "ROWS", "DATA":
+			if err := decodeRows(dec, result); err != nil {
+				return nil, err
+			}
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if returnCode < 0 {
+		returnCode = messagesReturnCode(result.Messages)
+	}
+	result.ReturnCode = returnCode
+
+	return result, nil
+}
+
+func parseJSONMessages(raw json.RawMessage) []CommandMessage {
+	var lines []string
+	if err := json.Unmarshal(raw, &lines); err != nil {
+		var line string
+		if err := json.Unmarshal(raw, &line); err != nil {
+			return []CommandMessage{{Text: string(raw)}}
+		}
+		lines = strings.Split(line, "\n")
+	}
+
+	var messages []CommandMessage
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			messages = append(messages, parseMessage(line))
+		}
+	}
+	return messages
+}
+
+// decodeRows reads an array of JSON objects, keeping the column order of the first object
+func decodeRows(dec *json.Decoder, result *CommandResult) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("Unexpected %v in command output items", tok)
+	}
+
+	seen := make(map[string]bool)
+	for _, col := range result.Columns {
+		seen[col] = true
+	}
+
+	for dec.More() {
+		if tok, err = dec.Token(); err != nil {
+			return err
+		}
+		if d, ok := tok.(json.Delim); !ok || d != '{' {
+			return fmt.Errorf("Unexpected %v in command output items", tok)
+		}
+
+		row := make(CommandRow)
+		for dec.More() {
+			if tok, err = dec.Token(); err != nil {
+				return err
+			}
+			col, _ := tok.(string)
+			if !seen[col] {
+				seen[col] = true
+				result.Columns = append(result.Columns, col)
+			}
+
+			if tok, err = dec.Token(); err != nil {
+				return err
+			}
+			switch v := tok.(type) {
+			case nil:
+			case string:
+				row[col] = v
+			case json.Number:
+				row[col] = v.String()
+			case bool:
+				row[col] = strconv.FormatBool(v)
+			default:
+				return fmt.Errorf("Unexpected %v in column %s of command output", tok, col)
+			}
+		}
+
+		// closing brace of the row
+		if _, err = dec.Token(); err != nil {
+			return err
+		}
+		result.Rows = append(result.Rows, row)
+	}
+
+	// closing bracket of the items
+	_, err = dec.Token()
+	return err
+}
+
+var separatorRe = regexp.MustCompile(`^\s*-+(\s+-+)*\s*$`)
+
+// parseCommandText parses the text output of an administrative command. Both the
+// standard format, with column headings underlined by dashes, and the detailed
+// format (F=D), with one "Name: value" line per column, are recognized.
+func parseCommandText(text string) *CommandResult {
+	result := new(CommandResult)
+
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if messageLineRe.MatchString(line) {
+			result.Messages = append(result.Messages, parseMessage(line))
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	sep := -1
+	for i, line := range lines {
+		if separatorRe.MatchString(line) {
+			sep = i
+			break
+		}
+	}
+
+	if sep > 0 {
+		parseStandardTable(lines, sep, result)
+	} else {
+		parseDetailedTable(lines, result)
+	}
+
+	result.ReturnCode = messagesReturnCode(result.Messages)
+
+	return result
+}
+
+type textColumn struct {
+	name       string
+	start, end int
+}
+
+func parseStandardTable(lines []string, sep int, result *CommandResult) {
+	var columns []textColumn
+	dashes := lines[sep]
+	for i := 0; i < len(dashes); {
+		if dashes[i] != '-' {
+			i++
+			continue
+		}
+		start := i
+		for i < len(dashes) && dashes[i] == '-' {
+			i++
+		}
+		columns = append(columns, textColumn{start: start, end: i})
+	}
+
+	// Headings can wrap over several lines above the dashes
+	first := sep - 1
+	for first > 0 && strings.TrimSpace(lines[first-1]) != "" {
+		first--
+	}
+	for i := range columns {
+		var name string
+		for _, line := range lines[first:sep] {
+			part := columnText(line, columns, i)
+			switch {
+			case part == "":
+			case name == "":
+				name = part
+			case strings.HasSuffix(name, "-"):
+				// Words are hyphenated when they wrap
+				name = name[:len(name)-1] + part
+			default:
+				name = name + " " + part
+			}
+		}
+		columns[i].name = name
+		result.Columns = append(result.Columns, columns[i].name)
+	}
+
+	for _, line := range lines[sep+1:] {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		row := make(CommandRow)
+		for i, col := range columns {
+			row[col.name] = columnText(line, columns, i)
+		}
+		result.Rows = append(result.Rows, row)
+	}
+}
+
+// columnText returns the text of line under column i. Values that overflow
+// their column up to the next one are kept whole.
+func columnText(line string, columns []textColumn, i int) string {
+	start := columns[i].start
+	if start >= len(line) {
+		return ""
+	}
+
+	end := len(line)
+	if i+1 < len(columns) && columns[i+1].start < end {
+		end = columns[i+1].start
+	}
+	return strings.TrimSpace(line[start:end])
+}
+
+func parseDetailedTable(lines []string, result *CommandResult) {
+	seen := make(map[string]bool)
+	var row CommandRow
+	var lastCol string
+
+	flush := func() {
+		if row != nil {
+			result.Rows = append(result.Rows, row)
+			row = nil
+		}
+	}
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+
+		idx := strings.Index(line, ": ")
+		if idx < 0 && strings.HasSuffix(line, ":") {
+			idx = len(line) - 1
+		}
+		if idx < 0 {
+			// Long values wrap onto lines without a column name
+			if row != nil && lastCol != "" {
+				row[lastCol] = strings.TrimSpace(row[lastCol] + " " + strings.TrimSpace(line))
+			}
+			continue
+		}
+
+		col := strings.TrimSpace(line[:idx])
+		value := strings.TrimSpace(line[idx+1:])
+		if row == nil {
+			row = make(CommandRow)
+		}
+		if !seen[col] {
+			seen[col] = true
+			result.Columns = append(result.Columns, col)
+		}
+		row[col] = value
+		lastCol = col
+	}
+	flush()
+}
+
+// messagesReturnCode estimates the return code of a command from its messages
+// when the Operations Center does not report one
+func messagesReturnCode(messages []CommandMessage) int {
+	rc := rcOK
+	for _, m := range messages {
+		switch {
+		case m.Number == "ANR2034E":
+			return rcNotFound
+		case m.IsError():
+			rc = rcError
+		}
+	}
+	return rc
+}
@@ -0,0 +1,135 @@
+package gospoc
+
+import (
+	"reflect"
+	"testing"
+)
+
+const queryNodeText = `ANR2017I Administrator ADMIN issued command: QUERY NODE
+
+Node Name                  Platform      Policy Domain   Days Since  Locked?
+                                         Name            Last Acce-
+                                                                 ss
+-------------------------  ------------  --------------  ----------  -------
+CLIENT1                    WinNT         STANDARD                 1  No
+VERYLONGNODENAMEOVERFLOW01 Linux x86-64  VMWARE                  <1  Yes
+NEWNODE                    Linux x86-64  STANDARD                    No
+`
+
+const queryAdminDetailedText = `ANR2017I Administrator ADMIN issued command: QUERY ADMIN * FORMAT=DETAILED
+
+             Administrator Name: ADMIN
+          Last Access Date/Time: 10/14/2026 09:12:01
+                        Contact: Storage team, extension 4321, on call
+                                 weekends and holidays
+                  Email Address:
+                         Locked?: No
+
+             Administrator Name: JDOE
+          Last Access Date/Time:
+                        Contact:
+                  Email Address: jdoe@example.com
+                         Locked?: Yes
+
+`
+
+func TestParseCommandResult(t *testing.T) {
+	issued := CommandMessage{Number: "ANR2017I", Severity: SeverityInformation, Text: "Administrator ADMIN issued command: QUERY NODE"}
+	noMatch := CommandMessage{Number: "ANR2034E", Severity: SeverityError, Text: "SELECT: No match found using this criteria."}
+
+	tests := []struct {
+		name     string
+		output   string
+		expected *CommandResult
+	}{
+		{
+			name:   "standard text with wrapped headings and overflowing values",
+			output: queryNodeText,
+			expected: &CommandResult{
+				Messages: []CommandMessage{issued},
+				Columns:  []string{"Node Name", "Platform", "Policy Domain Name", "Days Since Last Access", "Locked?"},
+				Rows: []CommandRow{
+					{"Node Name": "CLIENT1", "Platform": "WinNT", "Policy Domain Name": "STANDARD", "Days Since Last Access": "1", "Locked?": "No"},
+					{"Node Name": "VERYLONGNODENAMEOVERFLOW01", "Platform": "Linux x86-64", "Policy Domain Name": "VMWARE", "Days Since Last Access": "<1", "Locked?": "Yes"},
+					{"Node Name": "NEWNODE", "Platform": "Linux x86-64", "Policy Domain Name": "STANDARD", "Days Since Last Access": "", "Locked?": "No"},
+				},
+			},
+		},
+		{
+			name:   "detailed text with continuation lines and empty values",
+			output: queryAdminDetailedText,
+			expected: &CommandResult{
+				Messages: []CommandMessage{{Number: "ANR2017I", Severity: SeverityInformation, Text: "Administrator ADMIN issued command: QUERY ADMIN * FORMAT=DETAILED"}},
+				Columns:  []string{"Administrator Name", "Last Access Date/Time", "Contact", "Email Address", "Locked?"},
+				Rows: []CommandRow{
+					{"Administrator Name": "ADMIN", "Last Access Date/Time": "10/14/2026 09:12:01", "Contact": "Storage team, extension 4321, on call weekends and holidays", "Email Address": "", "Locked?": "No"},
+					{"Administrator Name": "JDOE", "Last Access Date/Time": "", "Contact": "", "Email Address": "jdoe@example.com", "Locked?": "Yes"},
+				},
+			},
+		},
+		{
+			name:   "text without rows",
+			output: "ANR2034E SELECT: No match found using this criteria.\nANS8001I Return code 11.\n",
+			expected: &CommandResult{
+				ReturnCode: rcNotFound,
+				Messages:   []CommandMessage{noMatch, {Number: "ANS8001I", Severity: SeverityInformation, Text: "Return code 11."}},
+			},
+		},
+		{
+			name: "JSON with NULL values and columns missing from the first row",
+			output: `{"MESSAGES":["ANR2017I Administrator ADMIN issued command: SELECT * FROM NODES"],
+				"ITEMS":[
+					{"NODE_NAME":"CLIENT1","TCP_ADDRESS":null,"NUM_FILES":1234,"LOCKED":false},
+					{"NODE_NAME":"CLIENT2","TCP_ADDRESS":"10.0.0.2","NUM_FILES":0,"LOCKED":true,"CONTACT":"Jane Doe"}
+				],"RC":0}`,
+			expected: &CommandResult{
+				Messages: []CommandMessage{{Number: "ANR2017I", Severity: SeverityInformation, Text: "Administrator ADMIN issued command: SELECT * FROM NODES"}},
+				Columns:  []string{"NODE_NAME", "TCP_ADDRESS", "NUM_FILES", "LOCKED", "CONTACT"},
+				Rows: []CommandRow{
+					{"NODE_NAME": "CLIENT1", "NUM_FILES": "1234", "LOCKED": "false"},
+					{"NODE_NAME": "CLIENT2", "TCP_ADDRESS": "10.0.0.2", "NUM_FILES": "0", "LOCKED": "true", "CONTACT": "Jane Doe"},
+				},
+			},
+		},
+		{
+			name:   "JSON with a single message string and no return code",
+			output: `{"MESSAGE":"ANR2017I Administrator ADMIN issued command: SELECT * FROM NODES\nANR2034E SELECT: No match found using this criteria.","ITEMS":null}`,
+			expected: &CommandResult{
+				ReturnCode: rcNotFound,
+				Messages:   []CommandMessage{{Number: "ANR2017I", Severity: SeverityInformation, Text: "Administrator ADMIN issued command: SELECT * FROM NODES"}, noMatch},
+			},
+		},
+		{
+			name:   "JSON with an error return code",
+			output: `{"MESSAGES":["ANR2020E UPDATE NODE: Invalid parameter - FOO."],"RC":3}`,
+			expected: &CommandResult{
+				ReturnCode: 3,
+				Messages:   []CommandMessage{{Number: "ANR2020E", Severity: SeverityError, Text: "UPDATE NODE: Invalid parameter - FOO."}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		result, err := parseCommandResult([]byte(tt.output))
+		if err != nil {
+			t.Errorf("[%s] parseCommandResult returned error: %v", tt.name, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Errorf("[%s] parseCommandResult returned %+v, expected %+v", tt.name, result, tt.expected)
+		}
+	}
+}
+
+func TestParseCommandResult_InvalidJSON(t *testing.T) {
+	for _, output := range []string{
+		`{"ITEMS":[["CLIENT1"]]}`,
+		`{"ITEMS":[{"NODE_NAME":{"NESTED":1}}]}`,
+		`{"RC":"zero"}`,
+	} {
+		if _, err := parseCommandResult([]byte(output)); err == nil {
+			t.Errorf("parseCommandResult of %s did not return an error", output)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package gospoc
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// DeviceClasses is an interface for interacting with
+// IBM Spectrum Protect device classes
+type DeviceClasses interface {
+	Get(ctx context.Context, serverName string, className string) (*DeviceClass, *http.Response, error)
+	List(ctx context.Context, serverName string) ([]DeviceClass, *http.Response, error)
+}
+
+// DeviceClassesOp handles communication with the device class related methods of the
+// IBM Spectrum Protect Operations Center REST API
+type DeviceClassesOp struct {
+	client *Client
+}
+
+// DeviceClass contains the elements that make up a device class
+type DeviceClass struct {
+	Name           string `spoc:"DEVCLASS_NAME"`
+	AccessStrategy string `spoc:"ACCESS_STRATEGY,nullzero"`
+	PoolCount      int    `spoc:"STGPOOL_COUNT,nullzero"`
+	DeviceType     string `spoc:"DEVTYPE,nullzero"`
+	Format         string `spoc:"FORMAT,nullzero"`
+
+	// Capacity and MountLimit are reported as set, such as 1.5T or DRIVES
+	Capacity       string    `spoc:"CAPACITY,nullzero"`
+	MountLimit     string    `spoc:"MOUNTLIMIT,nullzero"`
+	MountWait      int       `spoc:"MOUNTWAIT,nullzero"`
+	MountRetention int       `spoc:"MOUNTRETENTION,nullzero"`
+	Prefix         string    `spoc:"PREFIX,nullzero"`
+	Library        string    `spoc:"LIBRARY_NAME,nullzero"`
+	Directory      string    `spoc:"DIRECTORY,nullzero"`
+	ChangedBy      string    `spoc:"LAST_UPDATE_BY,nullzero"`
+	ChangedAt      time.Time `spoc:"LAST_UPDATE,nullzero"`
+}
+
+// List all device classes of a backup server
+func (s *DeviceClassesOp) List(ctx context.Context, serverName string) ([]DeviceClass, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	var classes []DeviceClass
+	resp, err := s.client.CLI.Query(ctx, serverName, selectFrom("DEVCLASSES"), &classes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return classes, resp, err
+}
+
+// Get the details of a specific device class
+func (s *DeviceClassesOp) Get(ctx context.Context, serverName string, className string) (*DeviceClass, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if className == "" {
+		return nil, nil, NewArgError("className", "cannot be empty")
+	}
+
+	var classes []DeviceClass
+	resp, err := s.client.CLI.Query(ctx, serverName, selectFrom("DEVCLASSES", sqlName("DEVCLASS_NAME", className)), &classes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(classes) == 0 {
+		return nil, resp, notFound("device class "+className, serverName)
+	}
+
+	return &classes[0], resp, err
+}
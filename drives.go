@@ -0,0 +1,100 @@
+package gospoc
+
+import (
+	"context"
+	"net/http"
+)
+
+// Drives is an interface for interacting with
+// IBM Spectrum Protect drives
+type Drives interface {
+	Get(ctx context.Context, serverName string, libraryName string, driveName string) (*Drive, *http.Response, error)
+	List(ctx context.Context, serverName string, libraryName string) ([]Drive, *http.Response, error)
+	SetOnline(ctx context.Context, serverName string, libraryName string, driveName string, online bool) (*http.Response, error)
+}
+
+// DrivesOp handles communication with the drive related methods of the
+// IBM Spectrum Protect Operations Center REST API
+type DrivesOp struct {
+	client *Client
+}
+
+// Drive contains the elements that make up a drive
+type Drive struct {
+	Library      string `spoc:"LIBRARY_NAME"`
+	Name         string `spoc:"DRIVE_NAME"`
+	DeviceType   string `spoc:"DEVICE_TYPE,nullzero"`
+	Online       bool   `spoc:"ONLINE,nullzero"`
+	State        string `spoc:"DRIVE_STATE,nullzero"`
+	Volume       string `spoc:"VOLUME_NAME,nullzero"`
+	AllocatedTo  string `spoc:"ALLOCATED_TO,nullzero"`
+	Element      string `spoc:"ELEMENT,nullzero"`
+	Vendor       string `spoc:"DRIVE_VENDOR,nullzero"`
+	Model        string `spoc:"DRIVE_MODEL,nullzero"`
+	Serial       string `spoc:"DRIVE_SERIAL,nullzero"`
+	ReadFormats  string `spoc:"READ_FORMATS,nullzero"`
+	WriteFormats string `spoc:"WRITE_FORMATS,nullzero"`
+}
+
+// List the drives of a library, or of every library when libraryName is empty
+func (s *DrivesOp) List(ctx context.Context, serverName string, libraryName string) ([]Drive, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	var drives []Drive
+	resp, err := s.client.CLI.Query(ctx, serverName, selectFrom("DRIVES", sqlName("LIBRARY_NAME", libraryName)), &drives)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return drives, resp, err
+}
+
+// Get the details of a specific drive
+func (s *DrivesOp) Get(ctx context.Context, serverName string, libraryName string, driveName string) (*Drive, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if libraryName == "" {
+		return nil, nil, NewArgError("libraryName", "cannot be empty")
+	}
+
+	if driveName == "" {
+		return nil, nil, NewArgError("driveName", "cannot be empty")
+	}
+
+	var drives []Drive
+	query := selectFrom("DRIVES", sqlName("LIBRARY_NAME", libraryName), sqlName("DRIVE_NAME", driveName))
+	resp, err := s.client.CLI.Query(ctx, serverName, query, &drives)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(drives) == 0 {
+		return nil, resp, notFound("drive "+libraryName+"/"+driveName, serverName)
+	}
+
+	return &drives[0], resp, err
+}
+
+// SetOnline makes a drive available to the server, or takes it offline
+func (s *DrivesOp) SetOnline(ctx context.Context, serverName string, libraryName string, driveName string, online bool) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if libraryName == "" {
+		return nil, NewArgError("libraryName", "cannot be empty")
+	}
+
+	if driveName == "" {
+		return nil, NewArgError("driveName", "cannot be empty")
+	}
+
+	cmd := newCommand("UPDATE", "DRIVE").arg(libraryName).arg(driveName).paramBool("ONLINE", &online)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
@@ -1,6 +1,12 @@
 package gospoc
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
 
 // ArgError is an error that represents an error with an input to godo. It
 // identifies the argument and the cause (if possible).
@@ -22,3 +28,157 @@ func NewArgError(arg, reason string) *ArgError {
 func (e *ArgError) Error() string {
 	return fmt.Sprintf("%s is invalid because %s", e.arg, e.reason)
 }
+
+// Errors that API errors can be compared to with errors.Is
+var (
+	ErrNotFound          = errors.New("not found")
+	ErrUnauthorized      = errors.New("not authenticated")
+	ErrForbidden         = errors.New("not authorized")
+	ErrConflict          = errors.New("already exists")
+	ErrServerUnavailable = errors.New("server unavailable")
+)
+
+// messageNumberRe matches IBM Spectrum Protect message numbers such as ANR2034E
+var messageNumberRe = regexp.MustCompile(`\b(AN[RSE][0-9]{4}[IEWSKD])\b`)
+
+// parseMessageNumber returns the first message number found in s, preferring
+// error and warning messages over informational ones
+func parseMessageNumber(s string) string {
+	matches := messageNumberRe.FindAllString(s, -1)
+	for _, m := range matches {
+		if !strings.HasSuffix(m, "I") {
+			return m
+		}
+	}
+	if len(matches) > 0 {
+		return matches[0]
+	}
+	return ""
+}
+
+// statusError returns the sentinel error matching an HTTP status code, if any
+func statusError(code int) error {
+	switch code {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return ErrServerUnavailable
+	}
+	return nil
+}
+
+// messageErrors maps IBM Spectrum Protect message numbers to sentinel errors
+var messageErrors = map[string]error{
+	"ANR2034E": ErrNotFound,
+}
+
+// messageError returns the sentinel error matching a message number or, for
+// unknown numbers, the message text, if any
+func messageError(number string, text string) error {
+	if err, ok := messageErrors[number]; ok {
+		return err
+	}
+
+	text = strings.ToLower(text)
+	switch {
+	case strings.Contains(text, "already exists"), strings.Contains(text, "already defined"):
+		return ErrConflict
+	case strings.Contains(text, "not found"), strings.Contains(text, "is not defined"):
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Return codes of IBM Spectrum Protect administrative commands
+const (
+	rcOK           = 0
+	rcError        = 4
+	rcNotAuth      = 9
+	rcExists       = 10
+	rcNotFound     = 11
+	rcNotAvailable = 14
+)
+
+// A CommandError reports an administrative command that completed with a
+// non-zero return code
+type CommandError struct {
+	// Server the command was issued to
+	Server string
+
+	// Command that failed
+	Command string
+
+	// Return code of the command
+	ReturnCode int
+
+	// IBM Spectrum Protect message number explaining the failure, such as ANR2034E
+	MessageNumber string
+
+	// Messages returned by the command
+	Messages []CommandMessage
+}
+
+func (e *CommandError) Error() string {
+	msg := fmt.Sprintf("command %q failed with return code %d", e.Command, e.ReturnCode)
+	if e.Server != "" {
+		msg = fmt.Sprintf("command %q on server %s failed with return code %d", e.Command, e.Server, e.ReturnCode)
+	}
+
+	for _, m := range e.Messages {
+		if m.IsError() {
+			return msg + ": " + m.String()
+		}
+	}
+
+	return msg
+}
+
+// Is reports whether the error matches one of the sentinel errors such as ErrNotFound
+func (e *CommandError) Is(target error) bool {
+	switch e.ReturnCode {
+	case rcNotFound:
+		return target == ErrNotFound
+	case rcNotAuth:
+		return target == ErrForbidden
+	case rcExists:
+		return target == ErrConflict
+	case rcNotAvailable:
+		return target == ErrServerUnavailable
+	}
+
+	for _, m := range e.Messages {
+		if m.IsError() {
+			return target != nil && target == messageError(m.Number, m.Text)
+		}
+	}
+	return false
+}
+
+func newCommandError(result *CommandResult) *CommandError {
+	e := &CommandError{
+		Server:     result.Server,
+		Command:    result.Command,
+		ReturnCode: result.ReturnCode,
+		Messages:   result.Messages,
+	}
+
+	for _, m := range result.Messages {
+		if m.IsError() {
+			e.MessageNumber = m.Number
+			break
+		}
+	}
+
+	return e
+}
+
+// notFound returns an error matching ErrNotFound for an object missing from a server
+func notFound(object string, serverName string) error {
+	return fmt.Errorf("Unable to find %s on server %s: %w", object, serverName, ErrNotFound)
+}
@@ -0,0 +1,155 @@
+package gospoc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// EventStatus is the status of a scheduled event
+type EventStatus string
+
+// Event statuses
+const (
+	EventCompleted  EventStatus = "Completed"
+	EventMissed     EventStatus = "Missed"
+	EventFailed     EventStatus = "Failed"
+	EventSevered    EventStatus = "Severed"
+	EventInProgress EventStatus = "In Progress"
+	EventStarted    EventStatus = "Started"
+	EventRestarted  EventStatus = "Restarted"
+	EventPending    EventStatus = "Pending"
+	EventFuture     EventStatus = "Future"
+	EventUncertain  EventStatus = "Uncertain"
+)
+
+// IsProblem reports whether the event missed, failed or was severed
+func (s EventStatus) IsProblem() bool {
+	switch s {
+	case EventMissed, EventFailed, EventSevered:
+		return true
+	}
+	return s.matches(EventFailed)
+}
+
+// matches reports whether s is status, ignoring case. Failed also matches the
+// statuses that carry a return code, such as "Failed - 12".
+func (s EventStatus) matches(status EventStatus) bool {
+	if strings.EqualFold(string(s), string(status)) {
+		return true
+	}
+	return status == EventFailed && len(s) > len(EventFailed) &&
+		strings.EqualFold(string(s[:len(EventFailed)]), string(EventFailed))
+}
+
+// Events is an interface for querying the results of
+// IBM Spectrum Protect client schedules
+type Events interface {
+	List(ctx context.Context, serverName string, filter *EventFilter) ([]ScheduleEvent, *http.Response, error)
+	ListAll(ctx context.Context, opts *FanOutOptions, filter *EventFilter) ([]ScheduleEvent, error)
+}
+
+// EventsOp handles communication with the event related methods of the
+// IBM Spectrum Protect Operations Center REST API
+type EventsOp struct {
+	client *Client
+}
+
+// ScheduleEvent is the result of a client schedule for one client
+type ScheduleEvent struct {
+	Server         string      `spoc:"-"`
+	Domain         string      `spoc:"DOMAIN_NAME,nullzero"`
+	Schedule       string      `spoc:"SCHEDULE_NAME,nullzero"`
+	Client         string      `spoc:"NODE_NAME,nullzero"`
+	Status         EventStatus `spoc:"STATUS,nullzero"`
+	ScheduledStart time.Time   `spoc:"SCHEDULED_START,nullzero"`
+	ActualStart    time.Time   `spoc:"ACTUAL_START,nullzero"`
+	Completed      time.Time   `spoc:"COMPLETED,nullzero"`
+	Result         int         `spoc:"RESULT,nullzero"`
+	Reason         string      `spoc:"REASON,nullzero"`
+}
+
+// EventFilter selects schedule events. Empty fields match every event.
+type EventFilter struct {
+	Domain   string
+	Schedule string
+	Client   string
+
+	// Begin and End limit the scheduled start of the events. The server
+	// only returns the events of the current day when both are zero.
+	Begin time.Time
+	End   time.Time
+
+	// Statuses are matched ignoring case. EventFailed also matches the failures
+	// reported with a return code, such as "Failed - 12".
+	Statuses []EventStatus
+}
+
+// List the schedule events of a backup server
+func (s *EventsOp) List(ctx context.Context, serverName string, filter *EventFilter) ([]ScheduleEvent, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if filter == nil {
+		filter = new(EventFilter)
+	}
+
+	var events []ScheduleEvent
+	query := selectFrom("EVENTS",
+		sqlName("DOMAIN_NAME", filter.Domain),
+		sqlName("SCHEDULE_NAME", filter.Schedule),
+		sqlName("NODE_NAME", filter.Client),
+		sqlTime("SCHEDULED_START", ">=", filter.Begin),
+		sqlTime("SCHEDULED_START", "<", filter.End))
+	resp, err := s.client.CLI.Query(ctx, serverName, query, &events)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	// Statuses are filtered here since the server formats them for display
+	filtered := events[:0]
+	for _, event := range events {
+		if filter.matchStatus(event.Status) {
+			event.Server = serverName
+			filtered = append(filtered, event)
+		}
+	}
+
+	return filtered, resp, err
+}
+
+// ListAll lists the schedule events of the servers selected by opts. The events of the servers
+// that could be queried are returned along with a *FanOutError for the others.
+func (s *EventsOp) ListAll(ctx context.Context, opts *FanOutOptions, filter *EventFilter) ([]ScheduleEvent, error) {
+	results, err := s.client.FanOut(ctx, opts, func(ctx context.Context, serverName string) (interface{}, *http.Response, error) {
+		events, resp, err := s.List(ctx, serverName, filter)
+		return events, resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var events []ScheduleEvent
+	for _, result := range results {
+		if serverEvents, ok := result.Value.([]ScheduleEvent); ok {
+			events = append(events, serverEvents...)
+		}
+	}
+
+	return events, results.Err()
+}
+
+func (f *EventFilter) matchStatus(status EventStatus) bool {
+	if len(f.Statuses) == 0 {
+		return true
+	}
+
+	for _, s := range f.Statuses {
+		if status.matches(s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,241 @@
+package gospoc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultFanOutConcurrency = 4
+
+// ServerFilter selects backup servers by their properties. Empty fields match every server.
+type ServerFilter struct {
+	// Names of the servers to select
+	Names []string
+
+	// Roles to select, compared without regard to case
+	Roles []string
+
+	// Statuses to select
+	Statuses []int
+
+	// VRMF prefix to select, such as "8.1" for every 8.1 server
+	VRMF string
+}
+
+// Match reports whether server is selected by the filter
+func (f *ServerFilter) Match(server BackupServer) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.Names) > 0 && !containsFold(f.Names, server.Name) {
+		return false
+	}
+
+	if len(f.Roles) > 0 && !containsFold(f.Roles, server.Role) {
+		return false
+	}
+
+	if len(f.Statuses) > 0 {
+		found := false
+		for _, status := range f.Statuses {
+			if status == server.Status {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return f.VRMF == "" || strings.HasPrefix(server.VRMF, f.VRMF)
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// FanOutOptions controls which servers an operation runs on and how
+type FanOutOptions struct {
+	// Servers to run the operation on. When empty, the operation runs on
+	// every server returned by BackupServers.List that matches Filter.
+	Servers []string
+
+	// Filter selects the servers when Servers is empty
+	Filter *ServerFilter
+
+	// Concurrency is the number of servers handled at the same time.
+	// It defaults to 4.
+	Concurrency int
+
+	// Timeout limits the time spent on each server. Zero means no limit
+	// other than the deadline of the context.
+	Timeout time.Duration
+}
+
+// FanOutFunc is an operation run on a single server by FanOut
+type FanOutFunc func(ctx context.Context, serverName string) (interface{}, *http.Response, error)
+
+// FanOutResult is the outcome of an operation on a single server
+type FanOutResult struct {
+	Server   string
+	Value    interface{}
+	Response *http.Response
+	Err      error
+}
+
+// FanOutResults are the outcomes of an operation on several servers
+type FanOutResults []FanOutResult
+
+// Failed returns the results with an error
+func (r FanOutResults) Failed() FanOutResults {
+	var failed FanOutResults
+	for _, result := range r {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err returns a *FanOutError when the operation failed on any server
+func (r FanOutResults) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+
+	e := &FanOutError{Errors: make(map[string]error), Total: len(r)}
+	for _, result := range failed {
+		e.Errors[result.Server] = result.Err
+	}
+	return e
+}
+
+// FanOutError reports the servers an operation failed on
+type FanOutError struct {
+	// Errors by server name
+	Errors map[string]error
+
+	// Number of servers the operation ran on
+	Total int
+}
+
+func (e *FanOutError) Error() string {
+	servers := make([]string, 0, len(e.Errors))
+	for server := range e.Errors {
+		servers = append(servers, server)
+	}
+	sort.Strings(servers)
+
+	msgs := make([]string, len(servers))
+	for i, server := range servers {
+		msgs[i] = server + ": " + e.Errors[server].Error()
+	}
+
+	return fmt.Sprintf("failed on %d of %d servers: %s", len(e.Errors), e.Total, strings.Join(msgs, "; "))
+}
+
+// FanOut runs fn concurrently on the servers selected by opts. It does not stop at the first
+// failure: the error of each server is reported in its result, in the order of the servers. An
+// error is only returned when the servers cannot be determined.
+func (c *Client) FanOut(ctx context.Context, opts *FanOutOptions, fn FanOutFunc) (FanOutResults, error) {
+	if fn == nil {
+		return nil, NewArgError("fn", "cannot be nil")
+	}
+
+	if opts == nil {
+		opts = new(FanOutOptions)
+	}
+
+	servers, err := c.fanOutServers(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultFanOutConcurrency
+	}
+
+	results := make(FanOutResults, len(servers))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, server := range servers {
+		results[i].Server = server
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(result *FanOutResult) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			serverCtx := ctx
+			if opts.Timeout > 0 {
+				var cancel context.CancelFunc
+				serverCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+				defer cancel()
+			}
+
+			result.Value, result.Response, result.Err = fn(serverCtx, result.Server)
+		}(&results[i])
+	}
+
+	wg.Wait()
+
+	return results, nil
+}
+
+// FanOutCommand issues command on the servers selected by opts. The Value of each result is
+// the *CommandResult of the command.
+func (c *Client) FanOutCommand(ctx context.Context, opts *FanOutOptions, command string) (FanOutResults, error) {
+	if command == "" {
+		return nil, NewArgError("command", "cannot be empty")
+	}
+
+	return c.FanOut(ctx, opts, func(ctx context.Context, serverName string) (interface{}, *http.Response, error) {
+		result, resp, err := c.CLI.IssueCommand(ctx, serverName, command)
+		if result == nil {
+			return nil, resp, err
+		}
+		return result, resp, err
+	})
+}
+
+func (c *Client) fanOutServers(ctx context.Context, opts *FanOutOptions) ([]string, error) {
+	if len(opts.Servers) > 0 {
+		return opts.Servers, nil
+	}
+
+	servers, _, err := c.Servers.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, server := range servers {
+		if opts.Filter.Match(server) {
+			names = append(names, server.Name)
+		}
+	}
+
+	return names, nil
+}
@@ -3,13 +3,13 @@ package gospoc
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 const (
@@ -28,6 +28,30 @@ type Config struct {
 	APIVersion string
 	URLScheme  string
 	SSLVerify  bool
+
+	// CACertFile and CACertPEM add PEM encoded CA certificates used to
+	// verify the Operations Center instead of the system roots
+	CACertFile string
+	CACertPEM  []byte
+
+	// ClientCertFile and ClientKeyFile, or ClientCertPEM and ClientKeyPEM,
+	// provide a client certificate for mutual TLS
+	ClientCertFile string
+	ClientKeyFile  string
+	ClientCertPEM  []byte
+	ClientKeyPEM   []byte
+
+	// MinTLSVersion is the minimum TLS version accepted, such as tls.VersionTLS12
+	MinTLSVersion uint16
+
+	// TLSServerName overrides the host name used to verify the server certificate
+	TLSServerName string
+
+	// PinnedCertSHA256 lists hex encoded SHA-256 fingerprints of the server
+	// certificates that are trusted. When set, the certificate chain is not
+	// verified, so Operations Center hosts with self-signed certificates can
+	// be used safely.
+	PinnedCertSHA256 []string
 }
 
 // Client is the API client for IBM Spectrum Protect Operations Center
@@ -38,14 +62,38 @@ type Client struct {
 
 	UserAgent string
 
-	CLI     CLI
-	Clients BackupClients
-	Domains BackupDomains
-	Servers BackupServers
+	ActivityLog    ActivityLog
+	AdminSchedules AdminSchedules
+	Administrators Administrators
+	Alerts         Alerts
+	CLI            CLI
+	Clients        BackupClients
+	DeviceClasses  DeviceClasses
+	Domains        BackupDomains
+	Drives         Drives
+	Events         Events
+	Health         Health
+	Libraries      Libraries
+	Occupancy      Occupancy
+	OptionSets     OptionSets
+	Paths          Paths
+	Policies       Policies
+	Processes      Processes
+	Replication    Replication
+	Schedules      Schedules
+	Scripts        Scripts
+	Servers        BackupServers
+	Sessions       Sessions
+	Storage        StoragePools
+	Volumes        Volumes
 
 	Config *Config
 
-	// Optional function called after every successful request made to the DO APIs
+	// RetryPolicy controls how failed requests are retried. A nil policy
+	// makes a single attempt per request.
+	RetryPolicy *RetryPolicy
+
+	// Optional function called after every successful request made to the Operations Center
 	onRequestCompleted RequestCompletionCallback
 }
 
@@ -54,34 +102,75 @@ type RequestCompletionCallback func(*http.Request, *http.Response)
 
 // NewClient returns a new IBM Spectrum Protect Operations Center REST API client
 func NewClient(config *Config) (*Client, error) {
+	return NewClientWithOptions(config)
+}
+
+// NewClientWithOptions returns a new IBM Spectrum Protect Operations Center REST API client
+// customized by the given options. The config is copied, so it is never modified and can be
+// used to create several clients.
+func NewClientWithOptions(config *Config, opts ...ClientOption) (*Client, error) {
+	if config == nil {
+		return nil, NewArgError("config", "cannot be nil")
+	}
+
+	cfg := *config
+
 	// Default to API Version 1.0
-	if config.APIVersion == "" {
-		config.APIVersion = "1.0"
+	if cfg.APIVersion == "" {
+		cfg.APIVersion = "1.0"
 	}
 
 	// Default to URL Scheme 7.1.4
-	if config.URLScheme == "" {
-		config.URLScheme = "7.1.4"
+	if cfg.URLScheme == "" {
+		cfg.URLScheme = "7.1.4"
 	}
 
-	if !validateURLScheme(config.URLScheme) {
-		return nil, fmt.Errorf("Invalid URL Scheme %s specified", config.URLScheme)
+	if !validateURLScheme(cfg.URLScheme) {
+		return nil, fmt.Errorf("Invalid URL Scheme %s specified", cfg.URLScheme)
 	}
 
-	defaultBaseURL := "https://" + config.OCHost
+	defaultBaseURL := "https://" + cfg.OCHost
 	baseURL, err := url.Parse(defaultBaseURL)
 	if err != nil {
 		return nil, err
 	}
 
-	if !config.SSLVerify {
-		http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	transport, err := newTransport(&cfg)
+	if err != nil {
+		return nil, err
 	}
 
-	c := &Client{client: http.DefaultClient, BaseURL: baseURL, UserAgent: userAgent, Config: config}
+	c := &Client{client: &http.Client{Transport: transport}, BaseURL: baseURL, UserAgent: userAgent, Config: &cfg, RetryPolicy: DefaultRetryPolicy()}
+	c.ActivityLog = &ActivityLogOp{client: c}
+	c.AdminSchedules = &AdminSchedulesOp{client: c}
+	c.Administrators = &AdministratorsOp{client: c}
+	c.Alerts = &AlertsOp{client: c}
 	c.CLI = &CLIOp{client: c}
 	c.Clients = &BackupClientsOp{client: c}
+	c.DeviceClasses = &DeviceClassesOp{client: c}
+	c.Domains = &BackupDomainsOp{client: c}
+	c.Drives = &DrivesOp{client: c}
+	c.Events = &EventsOp{client: c}
+	c.Health = &HealthOp{client: c}
+	c.Libraries = &LibrariesOp{client: c}
+	c.Occupancy = &OccupancyOp{client: c}
+	c.OptionSets = &OptionSetsOp{client: c}
+	c.Paths = &PathsOp{client: c}
+	c.Policies = &PoliciesOp{client: c}
+	c.Processes = &ProcessesOp{client: c}
+	c.Replication = &ReplicationOp{client: c}
+	c.Schedules = &SchedulesOp{client: c}
+	c.Scripts = &ScriptsOp{client: c}
 	c.Servers = &BackupServersOp{client: c}
+	c.Sessions = &SessionsOp{client: c}
+	c.Storage = &StoragePoolsOp{client: c}
+	c.Volumes = &VolumesOp{client: c}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
 
 	return c, nil
 }
@@ -122,10 +211,15 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body int
 
 // Do sends an API request and returns the API response. The API response is JSON decoded and stored in the value
 // pointed to by v, or returned as an error if an API error has occurred. If v implements the io.Writer interface,
-// the raw response will be written to v, without attempting to decode it.
+// the raw response will be written to v, without attempting to decode it. Failed requests are retried according to
+// the RetryPolicy of the Client, and an error returned after more than one attempt is a *RetryError. The number of
+// attempts made for a returned response is available through Attempts.
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
-	resp, err := DoRequestWithClient(ctx, c.client, req)
+	resp, attempts, err := doRequestWithRetry(ctx, c.client, req, c.RetryPolicy)
 	if err != nil {
+		if attempts > 1 {
+			err = &RetryError{Attempts: attempts, Err: err}
+		}
 		return nil, err
 	}
 	if c.onRequestCompleted != nil {
@@ -140,6 +234,9 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*htt
 
 	err = CheckResponse(resp)
 	if err != nil {
+		if attempts > 1 {
+			err = &RetryError{Attempts: attempts, Err: err}
+		}
 		return resp, err
 	}
 
@@ -182,16 +279,45 @@ func CheckResponse(r *http.Response) error {
 	if err == nil && len(data) > 0 {
 		err := json.Unmarshal(data, errorResponse)
 		if err != nil {
-			errorResponse.Message = string(data)
+			errorResponse.Message = strings.TrimSpace(string(data))
 		}
+		errorResponse.MessageNumber = parseMessageNumber(string(data))
+	}
+
+	if r.Request != nil && r.Request.URL != nil {
+		errorResponse.Operation = r.Request.Method + " " + r.Request.URL.Path
+		errorResponse.Server, errorResponse.Client = parseResourcePath(r.Request.URL.Path)
 	}
 
 	return errorResponse
 }
 
 func (r *ErrorResponse) Error() string {
-	return fmt.Sprintf("%v %v: %d %v",
-		r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.Message)
+	msg := fmt.Sprintf("%v %v: %d", r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode)
+	if r.MessageNumber != "" && !strings.HasPrefix(r.Message, r.MessageNumber) {
+		msg += " " + r.MessageNumber
+	}
+	if r.Message != "" {
+		msg += " " + r.Message
+	}
+
+	switch {
+	case r.Server != "" && r.Client != "":
+		msg += fmt.Sprintf(" (server %s, client %s)", r.Server, r.Client)
+	case r.Server != "":
+		msg += fmt.Sprintf(" (server %s)", r.Server)
+	}
+
+	return msg
+}
+
+// Is reports whether the error matches one of the sentinel errors such as ErrNotFound,
+// based on the HTTP status code, the message number or the message text
+func (r *ErrorResponse) Is(target error) bool {
+	if target == nil {
+		return false
+	}
+	return target == statusError(r.Response.StatusCode) || target == messageError(r.MessageNumber, r.Message)
 }
 
 // An ErrorResponse reports the error caused by an API request
@@ -201,6 +327,35 @@ type ErrorResponse struct {
 
 	// Error message
 	Message string `json:"message"`
+
+	// IBM Spectrum Protect message number found in the response, such as ANR2034E
+	MessageNumber string `json:"-"`
+
+	// HTTP method and path of the failed request
+	Operation string `json:"-"`
+
+	// Backup server and client the failed request refers to, if any
+	Server string `json:"-"`
+	Client string `json:"-"`
+}
+
+// parseResourcePath extracts the server and client names from an API path
+// such as /oc/api/servers/{server}/clients/{client}/details
+func parseResourcePath(path string) (server string, client string) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 0; i+1 < len(parts); i++ {
+		switch parts[i] {
+		case "servers", "issueCommand", "issueConfirmedCommand":
+			if server == "" {
+				server = parts[i+1]
+			}
+		case "clients":
+			if client == "" {
+				client = parts[i+1]
+			}
+		}
+	}
+	return server, client
 }
 
 func validateURLScheme(urlScheme string) bool {
@@ -0,0 +1,255 @@
+package gospoc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HealthSeverity rates the health of a server, from HealthOK to HealthCritical
+type HealthSeverity int
+
+// Health severities
+const (
+	HealthOK HealthSeverity = iota
+	HealthWarning
+	HealthCritical
+)
+
+func (s HealthSeverity) String() string {
+	switch s {
+	case HealthOK:
+		return "OK"
+	case HealthWarning:
+		return "WARNING"
+	case HealthCritical:
+		return "CRITICAL"
+	}
+	return fmt.Sprintf("HealthSeverity(%d)", int(s))
+}
+
+// Default health thresholds
+const (
+	DefaultHealthWarningPct    = 80
+	DefaultHealthCriticalPct   = 90
+	DefaultDBBackupMaxAge      = 24 * time.Hour
+	DefaultDBBackupCriticalAge = 48 * time.Hour
+)
+
+// Health is an interface for checking the database and recovery logs of
+// IBM Spectrum Protect servers
+type Health interface {
+	Get(ctx context.Context, serverName string, thresholds *HealthThresholds) (*ServerHealth, *http.Response, error)
+	GetAll(ctx context.Context, opts *FanOutOptions, thresholds *HealthThresholds) ([]ServerHealth, error)
+}
+
+// HealthOp handles communication with the server health related methods of the
+// IBM Spectrum Protect Operations Center REST API
+type HealthOp struct {
+	client *Client
+}
+
+// HealthThresholds sets when the space and database backups of a server become a
+// problem. Zero fields use the defaults.
+type HealthThresholds struct {
+	// WarningPct and CriticalPct are the percent of space used. They default to 80
+	// and 90, or to WarningPct when only a higher WarningPct is set.
+	WarningPct  float64
+	CriticalPct float64
+
+	// DBBackupMaxAge is the age of the last database backup making it overdue, a
+	// warning, and DBBackupCriticalAge a critical problem. They default to 24 hours
+	// and twice DBBackupMaxAge.
+	DBBackupMaxAge      time.Duration
+	DBBackupCriticalAge time.Duration
+}
+
+// SpaceUsage pairs the space used with its capacity
+type SpaceUsage struct {
+	Used     ByteSize
+	Capacity ByteSize
+	PctUsed  float64
+	Severity HealthSeverity
+}
+
+// ServerHealth is the health of the database and recovery logs of a server
+type ServerHealth struct {
+	Server string
+
+	Database   SpaceUsage
+	ActiveLog  SpaceUsage
+	ArchiveLog SpaceUsage
+
+	// LastDBBackup is zero when the database was never backed up
+	LastDBBackup     time.Time
+	DBBackupOverdue  bool
+	DBBackupSeverity HealthSeverity
+
+	// Severity is the worst severity of the server, and Problems describes
+	// what raised it above HealthOK
+	Severity HealthSeverity
+	Problems []string
+}
+
+type databaseRow struct {
+	UsedMB     float64   `spoc:"USED_DB_SPACE_MB,nullzero"`
+	FreeMB     float64   `spoc:"FREE_SPACE_MB,nullzero"`
+	LastBackup time.Time `spoc:"LAST_BACKUP_DATE,nullzero"`
+}
+
+type logRow struct {
+	TotalMB        float64 `spoc:"TOTAL_SPACE_MB,nullzero"`
+	UsedMB         float64 `spoc:"USED_SPACE_MB,nullzero"`
+	ArchiveTotalMB float64 `spoc:"ARCH_LOG_TOL_FS_MB,nullzero"`
+	ArchiveUsedMB  float64 `spoc:"ARCH_LOG_USED_FS_MB,nullzero"`
+}
+
+// Get the health of a backup server
+func (s *HealthOp) Get(ctx context.Context, serverName string, thresholds *HealthThresholds) (*ServerHealth, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	t, err := thresholds.withDefaults()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var db databaseRow
+	resp, err := s.client.CLI.Query(ctx, serverName, selectFrom("DB"), &db)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var log logRow
+	resp, err = s.client.CLI.Query(ctx, serverName, selectFrom("LOG"), &log)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	health := &ServerHealth{
+		Server: serverName,
+
+		// The database can only grow into the free space of its file systems
+		Database:     t.spaceUsage(db.UsedMB, db.UsedMB+db.FreeMB),
+		ActiveLog:    t.spaceUsage(log.UsedMB, log.TotalMB),
+		ArchiveLog:   t.spaceUsage(log.ArchiveUsedMB, log.ArchiveTotalMB),
+		LastDBBackup: db.LastBackup,
+	}
+
+	age := time.Since(db.LastBackup)
+	switch {
+	case db.LastBackup.IsZero() || age > t.DBBackupCriticalAge:
+		health.DBBackupSeverity = HealthCritical
+	case age > t.DBBackupMaxAge:
+		health.DBBackupSeverity = HealthWarning
+	}
+	health.DBBackupOverdue = health.DBBackupSeverity != HealthOK
+
+	health.check("database", health.Database)
+	health.check("active log", health.ActiveLog)
+	health.check("archive log", health.ArchiveLog)
+
+	if health.DBBackupOverdue {
+		problem := "database was never backed up"
+		if !db.LastBackup.IsZero() {
+			problem = fmt.Sprintf("last database backup ran %s ago", age.Round(time.Minute))
+		}
+		health.raise(health.DBBackupSeverity, problem)
+	}
+
+	return health, resp, err
+}
+
+// GetAll gets the health of the servers selected by opts. The health of the servers that
+// could be checked is returned along with a *FanOutError for the others.
+func (s *HealthOp) GetAll(ctx context.Context, opts *FanOutOptions, thresholds *HealthThresholds) ([]ServerHealth, error) {
+	if _, err := thresholds.withDefaults(); err != nil {
+		return nil, err
+	}
+
+	results, err := s.client.FanOut(ctx, opts, func(ctx context.Context, serverName string) (interface{}, *http.Response, error) {
+		health, resp, err := s.Get(ctx, serverName, thresholds)
+		return health, resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var list []ServerHealth
+	for _, result := range results {
+		if health, ok := result.Value.(*ServerHealth); ok && health != nil {
+			list = append(list, *health)
+		}
+	}
+
+	return list, results.Err()
+}
+
+func (h *ServerHealth) check(name string, usage SpaceUsage) {
+	if usage.Severity != HealthOK {
+		h.raise(usage.Severity, fmt.Sprintf("%s is %.1f%% full", name, usage.PctUsed))
+	}
+}
+
+func (h *ServerHealth) raise(severity HealthSeverity, problem string) {
+	if severity > h.Severity {
+		h.Severity = severity
+	}
+	h.Problems = append(h.Problems, problem)
+}
+
+// withDefaults returns the thresholds with the defaults filled in, or an error
+// when a critical threshold is below its warning threshold
+func (t *HealthThresholds) withDefaults() (HealthThresholds, error) {
+	var d HealthThresholds
+	if t != nil {
+		d = *t
+	}
+
+	if d.WarningPct <= 0 {
+		d.WarningPct = DefaultHealthWarningPct
+	}
+	if d.CriticalPct <= 0 {
+		d.CriticalPct = DefaultHealthCriticalPct
+		if d.WarningPct > d.CriticalPct {
+			d.CriticalPct = d.WarningPct
+		}
+	}
+	if d.DBBackupMaxAge <= 0 {
+		d.DBBackupMaxAge = DefaultDBBackupMaxAge
+	}
+	if d.DBBackupCriticalAge <= 0 {
+		d.DBBackupCriticalAge = DefaultDBBackupCriticalAge
+		if t != nil && t.DBBackupMaxAge > 0 {
+			d.DBBackupCriticalAge = 2 * t.DBBackupMaxAge
+		}
+	}
+
+	if d.CriticalPct < d.WarningPct {
+		return d, NewArgError("thresholds.CriticalPct", "cannot be below WarningPct")
+	}
+	if d.DBBackupCriticalAge < d.DBBackupMaxAge {
+		return d, NewArgError("thresholds.DBBackupCriticalAge", "cannot be below DBBackupMaxAge")
+	}
+
+	return d, nil
+}
+
+func (t *HealthThresholds) spaceUsage(usedMB float64, capacityMB float64) SpaceUsage {
+	usage := SpaceUsage{Used: byteSizeFromMB(usedMB), Capacity: byteSizeFromMB(capacityMB)}
+	if capacityMB <= 0 {
+		return usage
+	}
+
+	usage.PctUsed = usedMB / capacityMB * 100
+	switch {
+	case usage.PctUsed >= t.CriticalPct:
+		usage.Severity = HealthCritical
+	case usage.PctUsed >= t.WarningPct:
+		usage.Severity = HealthWarning
+	}
+
+	return usage
+}
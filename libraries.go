@@ -0,0 +1,290 @@
+package gospoc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Library volume statuses
+const (
+	LibraryVolumeScratch = "SCRATCH"
+	LibraryVolumePrivate = "PRIVATE"
+	LibraryVolumeCleaner = "CLEANER"
+)
+
+// Libraries is an interface for interacting with
+// IBM Spectrum Protect libraries and their volumes
+type Libraries interface {
+	CheckIn(ctx context.Context, serverName string, checkInRequest *CheckInRequest) (int64, *http.Response, error)
+	CheckOut(ctx context.Context, serverName string, checkOutRequest *CheckOutRequest) (int64, *http.Response, error)
+	Get(ctx context.Context, serverName string, libraryName string) (*Library, *http.Response, error)
+	List(ctx context.Context, serverName string) ([]Library, *http.Response, error)
+	ScratchCounts(ctx context.Context, serverName string) ([]ScratchCount, *http.Response, error)
+	Volumes(ctx context.Context, serverName string, libraryName string) ([]LibraryVolume, *http.Response, error)
+}
+
+// LibrariesOp handles communication with the library related methods of the
+// IBM Spectrum Protect Operations Center REST API
+type LibrariesOp struct {
+	client *Client
+}
+
+// Library contains the elements that make up a library
+type Library struct {
+	Name           string    `spoc:"LIBRARY_NAME"`
+	Type           string    `spoc:"LIBRARY_TYPE,nullzero"`
+	Shared         bool      `spoc:"SHARED,nullzero"`
+	PrimaryManager string    `spoc:"PRIMARY_LIB_MANAGER,nullzero"`
+	Serial         string    `spoc:"LIBRARY_SERIAL,nullzero"`
+	AutoLabel      string    `spoc:"AUTOLABEL,nullzero"`
+	ChangedBy      string    `spoc:"LAST_UPDATE_BY,nullzero"`
+	ChangedAt      time.Time `spoc:"LAST_UPDATE,nullzero"`
+}
+
+// LibraryVolume is a volume checked into a library
+type LibraryVolume struct {
+	Library string `spoc:"LIBRARY_NAME"`
+	Name    string `spoc:"VOLUME_NAME"`
+
+	// Status is Scratch, Private or Cleaner
+	Status        string    `spoc:"STATUS,nullzero"`
+	Owner         string    `spoc:"OWNER,nullzero"`
+	LastUse       string    `spoc:"LAST_USE,nullzero"`
+	HomeElement   int       `spoc:"HOME_ELEMENT,nullzero"`
+	CleaningsLeft int       `spoc:"CLEANINGS_LEFT,nullzero"`
+	DeviceType    string    `spoc:"DEVTYPE,nullzero"`
+	MediaType     string    `spoc:"MEDIATYPE,nullzero"`
+	ChangedAt     time.Time `spoc:"LAST_UPDATE,nullzero"`
+}
+
+// ScratchCount counts the volumes of a library by status
+type ScratchCount struct {
+	Server  string
+	Library string
+	Scratch int
+	Private int
+	Cleaner int
+	Total   int
+}
+
+// CheckInRequest represents a request to check volumes into a library
+type CheckInRequest struct {
+	Library string
+
+	// Volumes to check in. A single volume is checked in by name unless Search is
+	// set, otherwise the volumes limit the search, which defaults to YES.
+	Volumes []string
+
+	// Status is SCRATCH, PRIVATE or CLEANER
+	Status string
+
+	// Search is YES to search the library, or BULK to search its entry/exit ports
+	Search string
+
+	// CheckLabel is YES, NO or BARCODE
+	CheckLabel string
+	Swap       *bool
+	WaitTime   *int
+}
+
+// CheckOutRequest represents a request to check volumes out of a library
+type CheckOutRequest struct {
+	Library string
+	Volumes []string
+
+	// Remove is YES, NO or BULK
+	Remove     string
+	CheckLabel *bool
+}
+
+// List all libraries of a backup server
+func (s *LibrariesOp) List(ctx context.Context, serverName string) ([]Library, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	var libraries []Library
+	resp, err := s.client.CLI.Query(ctx, serverName, selectFrom("LIBRARIES"), &libraries)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return libraries, resp, err
+}
+
+// Get the details of a specific library
+func (s *LibrariesOp) Get(ctx context.Context, serverName string, libraryName string) (*Library, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if libraryName == "" {
+		return nil, nil, NewArgError("libraryName", "cannot be empty")
+	}
+
+	var libraries []Library
+	resp, err := s.client.CLI.Query(ctx, serverName, selectFrom("LIBRARIES", sqlName("LIBRARY_NAME", libraryName)), &libraries)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(libraries) == 0 {
+		return nil, resp, notFound("library "+libraryName, serverName)
+	}
+
+	return &libraries[0], resp, err
+}
+
+// Volumes lists the volumes checked into a library, or into every library when libraryName is empty
+func (s *LibrariesOp) Volumes(ctx context.Context, serverName string, libraryName string) ([]LibraryVolume, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	var volumes []LibraryVolume
+	resp, err := s.client.CLI.Query(ctx, serverName, selectFrom("LIBVOLUMES", sqlName("LIBRARY_NAME", libraryName)), &volumes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return volumes, resp, err
+}
+
+// ScratchCounts counts the volumes of every library of a backup server by status.
+// Libraries without volumes are reported with zero counts.
+func (s *LibrariesOp) ScratchCounts(ctx context.Context, serverName string) ([]ScratchCount, *http.Response, error) {
+	libraries, resp, err := s.List(ctx, serverName)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	volumes, resp, err := s.Volumes(ctx, serverName, "")
+	if err != nil {
+		return nil, resp, err
+	}
+
+	counts := make(map[string]*ScratchCount)
+	count := func(library string) *ScratchCount {
+		c, ok := counts[library]
+		if !ok {
+			c = &ScratchCount{Server: serverName, Library: library}
+			counts[library] = c
+		}
+		return c
+	}
+
+	for _, library := range libraries {
+		count(library.Name)
+	}
+
+	for _, volume := range volumes {
+		c := count(volume.Library)
+		c.Total++
+		switch strings.ToUpper(volume.Status) {
+		case LibraryVolumeScratch:
+			c.Scratch++
+		case LibraryVolumePrivate:
+			c.Private++
+		case LibraryVolumeCleaner:
+			c.Cleaner++
+		}
+	}
+
+	list := make([]ScratchCount, 0, len(counts))
+	for _, c := range counts {
+		list = append(list, *c)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Library < list[j].Library })
+
+	return list, resp, err
+}
+
+// CheckIn starts a background process checking volumes into a library and returns its number
+func (s *LibrariesOp) CheckIn(ctx context.Context, serverName string, checkInRequest *CheckInRequest) (int64, *http.Response, error) {
+	if checkInRequest == nil {
+		return 0, nil, NewArgError("checkInRequest", "cannot be nil")
+	}
+
+	if serverName == "" {
+		return 0, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if checkInRequest.Library == "" {
+		return 0, nil, NewArgError("checkInRequest.Library", "cannot be empty")
+	}
+
+	if checkInRequest.Status == "" {
+		return 0, nil, NewArgError("checkInRequest.Status", "cannot be empty")
+	}
+
+	if len(checkInRequest.Volumes) == 0 && checkInRequest.Search == "" {
+		return 0, nil, NewArgError("checkInRequest", "must list volumes or search the library")
+	}
+
+	search := checkInRequest.Search
+	cmd := newCommand("CHECKIN", "LIBVOLUME").arg(checkInRequest.Library)
+	if len(checkInRequest.Volumes) == 1 && search == "" {
+		cmd.arg(checkInRequest.Volumes[0])
+	} else {
+		// VOLLIST is only accepted along with SEARCH
+		if search == "" {
+			search = "YES"
+		}
+		cmd.paramList("VOLLIST", checkInRequest.Volumes)
+	}
+	cmd.param("STATUS", checkInRequest.Status).
+		param("SEARCH", search).
+		param("CHECKLABEL", checkInRequest.CheckLabel).
+		paramBool("SWAP", checkInRequest.Swap).
+		paramInt("WAITTIME", checkInRequest.WaitTime)
+
+	return s.startProcess(ctx, serverName, cmd, "check in volumes to library "+checkInRequest.Library)
+}
+
+// CheckOut starts a background process checking volumes out of a library and returns its number
+func (s *LibrariesOp) CheckOut(ctx context.Context, serverName string, checkOutRequest *CheckOutRequest) (int64, *http.Response, error) {
+	if checkOutRequest == nil {
+		return 0, nil, NewArgError("checkOutRequest", "cannot be nil")
+	}
+
+	if serverName == "" {
+		return 0, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if checkOutRequest.Library == "" {
+		return 0, nil, NewArgError("checkOutRequest.Library", "cannot be empty")
+	}
+
+	if len(checkOutRequest.Volumes) == 0 {
+		return 0, nil, NewArgError("checkOutRequest.Volumes", "cannot be empty")
+	}
+
+	cmd := newCommand("CHECKOUT", "LIBVOLUME").arg(checkOutRequest.Library)
+	if len(checkOutRequest.Volumes) == 1 {
+		cmd.arg(checkOutRequest.Volumes[0])
+	} else {
+		cmd.paramList("VOLLIST", checkOutRequest.Volumes)
+	}
+	cmd.param("REMOVE", checkOutRequest.Remove).
+		paramBool("CHECKLABEL", checkOutRequest.CheckLabel)
+
+	return s.startProcess(ctx, serverName, cmd, "check out volumes from library "+checkOutRequest.Library)
+}
+
+func (s *LibrariesOp) startProcess(ctx context.Context, serverName string, cmd *command, action string) (int64, *http.Response, error) {
+	result, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	if err != nil {
+		return 0, resp, err
+	}
+
+	processNumber, ok := startedProcess(result)
+	if !ok {
+		return 0, resp, fmt.Errorf("Unable to %s on server %s: %w", action, serverName, ErrNoProcess)
+	}
+
+	return processNumber, resp, err
+}
@@ -0,0 +1,247 @@
+package gospoc
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// OccupancyKey is a field occupancy is grouped by in Occupancy.Rollup
+type OccupancyKey string
+
+// Occupancy keys
+const (
+	OccupancyByServer OccupancyKey = "server"
+	OccupancyByDomain OccupancyKey = "domain"
+
+	// OccupancyByClient also groups by server, since client names are only unique
+	// per server, and by the domain of the client
+	OccupancyByClient      OccupancyKey = "client"
+	OccupancyByStoragePool OccupancyKey = "storagepool"
+)
+
+// Occupancy is an interface for reporting the storage used by
+// IBM Spectrum Protect backup clients
+type Occupancy interface {
+	List(ctx context.Context, serverName string, filter *OccupancyFilter) ([]OccupancyRecord, *http.Response, error)
+	Rollup(ctx context.Context, opts *FanOutOptions, groupBy ...OccupancyKey) ([]OccupancyTotal, error)
+}
+
+// OccupancyOp handles communication with the occupancy related methods of the
+// IBM Spectrum Protect Operations Center REST API
+type OccupancyOp struct {
+	client *Client
+}
+
+// OccupancyRecord is the storage used by a file space of a client in a storage pool
+type OccupancyRecord struct {
+	Server string
+
+	// Domain is only set by Occupancy.Rollup
+	Domain string
+	Client string
+
+	// Type is Bkup, Arch or SpMg
+	Type        string
+	FileSpace   string
+	FileSpaceID int
+	StoragePool string
+
+	NumFiles  int64
+	Physical  ByteSize
+	Logical   ByteSize
+	Reporting ByteSize
+}
+
+// OccupancyTotal is the storage used by a group of records. The fields
+// that are not grouped by are empty.
+type OccupancyTotal struct {
+	Server      string
+	Domain      string
+	Client      string
+	StoragePool string
+
+	NumFiles  int64
+	Physical  ByteSize
+	Logical   ByteSize
+	Reporting ByteSize
+}
+
+// OccupancyFilter selects occupancy records. Empty fields match every record.
+type OccupancyFilter struct {
+	Client      string
+	StoragePool string
+
+	// Type is Bkup, Arch or SpMg
+	Type string
+}
+
+type occupancyRow struct {
+	Client      string  `spoc:"NODE_NAME"`
+	Type        string  `spoc:"TYPE,nullzero"`
+	FileSpace   string  `spoc:"FILESPACE_NAME,nullzero"`
+	FileSpaceID int     `spoc:"FILESPACE_ID,nullzero"`
+	StoragePool string  `spoc:"STGPOOL_NAME,nullzero"`
+	NumFiles    int64   `spoc:"NUM_FILES,nullzero"`
+	PhysicalMB  float64 `spoc:"PHYSICAL_MB,nullzero"`
+	LogicalMB   float64 `spoc:"LOGICAL_MB,nullzero"`
+	ReportingMB float64 `spoc:"REPORTING_MB,nullzero"`
+}
+
+// List the occupancy of the clients of a backup server per file space and storage pool
+func (s *OccupancyOp) List(ctx context.Context, serverName string, filter *OccupancyFilter) ([]OccupancyRecord, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if filter == nil {
+		filter = new(OccupancyFilter)
+	}
+
+	var rows []occupancyRow
+	query := selectFrom("OCCUPANCY",
+		sqlName("NODE_NAME", filter.Client),
+		sqlName("STGPOOL_NAME", filter.StoragePool))
+	resp, err := s.client.CLI.Query(ctx, serverName, query, &rows)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var records []OccupancyRecord
+	for _, row := range rows {
+		// The type is stored in mixed case, so it is filtered here
+		if filter.Type != "" && !strings.EqualFold(filter.Type, row.Type) {
+			continue
+		}
+
+		records = append(records, OccupancyRecord{
+			Server:      serverName,
+			Client:      row.Client,
+			Type:        row.Type,
+			FileSpace:   row.FileSpace,
+			FileSpaceID: row.FileSpaceID,
+			StoragePool: row.StoragePool,
+			NumFiles:    row.NumFiles,
+			Physical:    byteSizeFromMB(row.PhysicalMB),
+			Logical:     byteSizeFromMB(row.LogicalMB),
+			Reporting:   byteSizeFromMB(row.ReportingMB),
+		})
+	}
+
+	return records, resp, err
+}
+
+// Rollup totals the occupancy of every client returned by BackupClients.List on the
+// servers selected by opts, grouped by the given keys. Clients without occupancy are
+// reported with zero totals unless the storage pool is grouped by. The totals of the
+// servers that could be queried are returned along with a *FanOutError for the others.
+func (s *OccupancyOp) Rollup(ctx context.Context, opts *FanOutOptions, groupBy ...OccupancyKey) ([]OccupancyTotal, error) {
+	for _, key := range groupBy {
+		switch key {
+		case OccupancyByServer, OccupancyByDomain, OccupancyByClient, OccupancyByStoragePool:
+		default:
+			return nil, NewArgError("groupBy", "unknown occupancy key "+string(key))
+		}
+	}
+
+	clients, _, err := s.client.Clients.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	domains := make(map[[2]string]string)
+	for _, client := range clients {
+		domains[[2]string{strings.ToUpper(client.Server), strings.ToUpper(client.Name)}] = client.Domain
+	}
+
+	results, err := s.client.FanOut(ctx, opts, func(ctx context.Context, serverName string) (interface{}, *http.Response, error) {
+		records, resp, err := s.List(ctx, serverName, nil)
+		return records, resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Totals are keyed by their grouped fields, with zero quantities
+	totals := make(map[OccupancyTotal]*OccupancyTotal)
+	add := func(record OccupancyRecord) {
+		key := groupKey(record, groupBy)
+		total, ok := totals[key]
+		if !ok {
+			total = &key
+			totals[key] = total
+		}
+		total.NumFiles += record.NumFiles
+		total.Physical += record.Physical
+		total.Logical += record.Logical
+		total.Reporting += record.Reporting
+	}
+
+	groupByPool := false
+	for _, key := range groupBy {
+		groupByPool = groupByPool || key == OccupancyByStoragePool
+	}
+
+	for _, result := range results {
+		records, ok := result.Value.([]OccupancyRecord)
+		if result.Err != nil || !ok {
+			continue
+		}
+
+		for _, record := range records {
+			record.Domain = domains[[2]string{strings.ToUpper(result.Server), strings.ToUpper(record.Client)}]
+			add(record)
+		}
+
+		if groupByPool {
+			continue
+		}
+		for _, client := range clients {
+			if strings.EqualFold(client.Server, result.Server) {
+				add(OccupancyRecord{Server: result.Server, Domain: client.Domain, Client: strings.ToUpper(client.Name)})
+			}
+		}
+	}
+
+	list := make([]OccupancyTotal, 0, len(totals))
+	for _, total := range totals {
+		list = append(list, *total)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if a.Server != b.Server {
+			return a.Server < b.Server
+		}
+		if a.Domain != b.Domain {
+			return a.Domain < b.Domain
+		}
+		if a.Client != b.Client {
+			return a.Client < b.Client
+		}
+		return a.StoragePool < b.StoragePool
+	})
+
+	return list, results.Err()
+}
+
+// groupKey returns the total a record is added to
+func groupKey(record OccupancyRecord, groupBy []OccupancyKey) OccupancyTotal {
+	var key OccupancyTotal
+	for _, k := range groupBy {
+		switch k {
+		case OccupancyByServer:
+			key.Server = record.Server
+		case OccupancyByDomain:
+			key.Domain = record.Domain
+		case OccupancyByClient:
+			key.Server = record.Server
+			key.Domain = record.Domain
+			key.Client = record.Client
+		case OccupancyByStoragePool:
+			key.StoragePool = record.StoragePool
+		}
+	}
+	return key
+}
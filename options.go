@@ -0,0 +1,104 @@
+package gospoc
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ClientOption customizes a Client created by NewClientWithOptions
+type ClientOption func(*Client) error
+
+// WithHTTPClient makes the Client send requests with hc. The TLS settings of
+// the Config are not applied to hc.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) error {
+		if hc == nil {
+			return NewArgError("hc", "cannot be nil")
+		}
+		c.client = hc
+		return nil
+	}
+}
+
+// WithRequestCompletedCallback sets a function called after every successful request
+func WithRequestCompletedCallback(callback RequestCompletionCallback) ClientOption {
+	return func(c *Client) error {
+		c.onRequestCompleted = callback
+		return nil
+	}
+}
+
+// WithTimeout sets the time limit for each attempt of a request
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) error {
+		if timeout < 0 {
+			return NewArgError("timeout", "cannot be negative")
+		}
+
+		// Copy the HTTP client so one passed to WithHTTPClient is not modified
+		hc := *c.client
+		hc.Timeout = timeout
+		c.client = &hc
+		return nil
+	}
+}
+
+// WithProxy sends requests through the proxy at proxyURL
+func WithProxy(proxyURL string) ClientOption {
+	return func(c *Client) error {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return err
+		}
+
+		transport := c.client.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+
+		t, ok := transport.(*http.Transport)
+		if !ok {
+			return fmt.Errorf("Unable to set a proxy on transport of type %T", transport)
+		}
+
+		t = t.Clone()
+		t.Proxy = http.ProxyURL(u)
+
+		hc := *c.client
+		hc.Transport = t
+		c.client = &hc
+		return nil
+	}
+}
+
+// WithUserAgentSuffix appends suffix to the User-Agent header sent with every request
+func WithUserAgentSuffix(suffix string) ClientOption {
+	return func(c *Client) error {
+		if suffix != "" {
+			c.UserAgent = c.UserAgent + " " + suffix
+		}
+		return nil
+	}
+}
+
+// WithBaseURL sends requests to baseURL instead of https://OCHost
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.BaseURL = u
+		return nil
+	}
+}
+
+// WithRetryPolicy replaces the default retry policy. A nil policy disables retries.
+func WithRetryPolicy(policy *RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		c.RetryPolicy = policy
+		return nil
+	}
+}
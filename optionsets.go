@@ -0,0 +1,344 @@
+package gospoc
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OptionSets is an interface for interacting with
+// IBM Spectrum Protect client option sets
+type OptionSets interface {
+	AddOption(ctx context.Context, serverName string, setName string, option *ClientOpt) (*http.Response, error)
+	Copy(ctx context.Context, serverName string, setName string, newSetName string) (*http.Response, error)
+	Create(ctx context.Context, serverName string, createRequest *OptionSetRequest) (*http.Response, error)
+	Delete(ctx context.Context, serverName string, setName string) (*http.Response, error)
+	Diff(ctx context.Context, serverName string, setName string, otherServerName string, otherSetName string) ([]OptionDifference, *http.Response, error)
+	Get(ctx context.Context, serverName string, setName string) (*OptionSet, *http.Response, error)
+	List(ctx context.Context, serverName string) ([]OptionSet, *http.Response, error)
+	RemoveOption(ctx context.Context, serverName string, setName string, optionName string, sequence *int) (*http.Response, error)
+}
+
+// OptionSetsOp handles communication with the client option set related methods of the
+// IBM Spectrum Protect Operations Center REST API
+type OptionSetsOp struct {
+	client *Client
+}
+
+// OptionSet contains the elements that make up a client option set
+type OptionSet struct {
+	Name        string
+	Description string
+	Profile     string
+	ChangedBy   string
+	ChangedAt   time.Time
+	Options     []ClientOpt
+}
+
+// ClientOpt is a client option of an option set
+type ClientOpt struct {
+	Name  string `spoc:"OPTION_NAME"`
+	Value string `spoc:"OPTION_VALUE,nullzero"`
+
+	// Sequence orders the options that can be set more than once, such as INCLEXCL
+	Sequence int `spoc:"SEQNUMBER,nullzero"`
+
+	// Force overrides the value set in the client options file
+	Force bool `spoc:"FORCE,nullzero"`
+}
+
+// OptionSetRequest represents a request to define a client option set
+type OptionSetRequest struct {
+	Name        string
+	Description string
+
+	// Options are added to the set once it is defined
+	Options []ClientOpt
+}
+
+// OptionDifference lists the values of an option that differ between two option sets.
+// The values are in sequence order, and empty when the option is not in the set.
+type OptionDifference struct {
+	Name  string
+	From  []ClientOpt
+	Other []ClientOpt
+}
+
+type optionSetRow struct {
+	Name        string    `spoc:"OPTIONSET_NAME"`
+	Description string    `spoc:"DESCRIPTION,nullzero"`
+	Profile     string    `spoc:"PROFILE,nullzero"`
+	ChangedBy   string    `spoc:"LAST_UPDATE_BY,nullzero"`
+	ChangedAt   time.Time `spoc:"LAST_UPDATE,nullzero"`
+}
+
+type clientOptRow struct {
+	Set string `spoc:"OPTIONSET_NAME"`
+	ClientOpt
+}
+
+// List all client option sets of a backup server along with their options
+func (s *OptionSetsOp) List(ctx context.Context, serverName string) ([]OptionSet, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	return s.list(ctx, serverName, "")
+}
+
+// Get a client option set along with its options
+func (s *OptionSetsOp) Get(ctx context.Context, serverName string, setName string) (*OptionSet, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if setName == "" {
+		return nil, nil, NewArgError("setName", "cannot be empty")
+	}
+
+	sets, resp, err := s.list(ctx, serverName, setName)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(sets) == 0 {
+		return nil, resp, notFound("option set "+setName, serverName)
+	}
+
+	return &sets[0], resp, err
+}
+
+func (s *OptionSetsOp) list(ctx context.Context, serverName string, setName string) ([]OptionSet, *http.Response, error) {
+	var rows []optionSetRow
+	resp, err := s.client.CLI.Query(ctx, serverName, selectFrom("CLOPTSETS", sqlName("OPTIONSET_NAME", setName)), &rows)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var options []clientOptRow
+	resp, err = s.client.CLI.Query(ctx, serverName, selectFrom("CLIENTOPTS", sqlName("OPTIONSET_NAME", setName)), &options)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	sets := make([]OptionSet, len(rows))
+	index := make(map[string]int)
+	for i, row := range rows {
+		sets[i] = OptionSet{Name: row.Name, Description: row.Description, Profile: row.Profile, ChangedBy: row.ChangedBy, ChangedAt: row.ChangedAt}
+		index[row.Name] = i
+	}
+
+	for _, option := range options {
+		if i, ok := index[option.Set]; ok {
+			sets[i].Options = append(sets[i].Options, option.ClientOpt)
+		}
+	}
+
+	for i := range sets {
+		sortClientOpts(sets[i].Options)
+	}
+
+	return sets, resp, err
+}
+
+// Create defines a new client option set along with its options
+func (s *OptionSetsOp) Create(ctx context.Context, serverName string, createRequest *OptionSetRequest) (*http.Response, error) {
+	if createRequest == nil {
+		return nil, NewArgError("createRequest", "cannot be nil")
+	}
+
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if createRequest.Name == "" {
+		return nil, NewArgError("createRequest.Name", "cannot be empty")
+	}
+
+	cmd := newCommand("DEFINE", "CLOPTSET").arg(createRequest.Name).param("DESCRIPTION", createRequest.Description)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	if err != nil {
+		return resp, err
+	}
+
+	for i := range createRequest.Options {
+		if resp, err = s.AddOption(ctx, serverName, createRequest.Name, &createRequest.Options[i]); err != nil {
+			return resp, err
+		}
+	}
+
+	return resp, err
+}
+
+// Copy a client option set along with its options to a new option set
+func (s *OptionSetsOp) Copy(ctx context.Context, serverName string, setName string, newSetName string) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if setName == "" {
+		return nil, NewArgError("setName", "cannot be empty")
+	}
+
+	if newSetName == "" {
+		return nil, NewArgError("newSetName", "cannot be empty")
+	}
+
+	cmd := newCommand("COPY", "CLOPTSET").arg(setName).arg(newSetName)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// Delete a client option set
+func (s *OptionSetsOp) Delete(ctx context.Context, serverName string, setName string) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if setName == "" {
+		return nil, NewArgError("setName", "cannot be empty")
+	}
+
+	cmd := newCommand("DELETE", "CLOPTSET").arg(setName)
+
+	_, resp, err := s.client.runConfirmedCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// AddOption adds a client option to an option set. A zero option.Sequence lets the
+// server number the option.
+func (s *OptionSetsOp) AddOption(ctx context.Context, serverName string, setName string, option *ClientOpt) (*http.Response, error) {
+	if option == nil {
+		return nil, NewArgError("option", "cannot be nil")
+	}
+
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if setName == "" {
+		return nil, NewArgError("setName", "cannot be empty")
+	}
+
+	if option.Name == "" {
+		return nil, NewArgError("option.Name", "cannot be empty")
+	}
+
+	var sequence *int
+	if option.Sequence > 0 {
+		sequence = &option.Sequence
+	}
+
+	cmd := newCommand("DEFINE", "CLIENTOPT").arg(setName).arg(option.Name).arg(option.Value).
+		paramBool("FORCE", &option.Force).
+		paramInt("SEQNUMBER", sequence)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// RemoveOption removes a client option from an option set. A nil sequence removes
+// every value of the option.
+func (s *OptionSetsOp) RemoveOption(ctx context.Context, serverName string, setName string, optionName string, sequence *int) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if setName == "" {
+		return nil, NewArgError("setName", "cannot be empty")
+	}
+
+	if optionName == "" {
+		return nil, NewArgError("optionName", "cannot be empty")
+	}
+
+	cmd := newCommand("DELETE", "CLIENTOPT").arg(setName).arg(optionName)
+	if sequence == nil {
+		cmd.param("SEQNUMBER", "ALL")
+	} else {
+		cmd.paramInt("SEQNUMBER", sequence)
+	}
+
+	_, resp, err := s.client.runConfirmedCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// Diff compares an option set to another one, on the same server or on another server,
+// and returns the options whose values differ
+func (s *OptionSetsOp) Diff(ctx context.Context, serverName string, setName string, otherServerName string, otherSetName string) ([]OptionDifference, *http.Response, error) {
+	if otherServerName == "" {
+		otherServerName = serverName
+	}
+
+	set, resp, err := s.Get(ctx, serverName, setName)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	other, resp, err := s.Get(ctx, otherServerName, otherSetName)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return diffClientOpts(set.Options, other.Options), resp, err
+}
+
+// diffClientOpts compares the values of each option in sequence order, ignoring the
+// sequence numbers themselves
+func diffClientOpts(from []ClientOpt, other []ClientOpt) []OptionDifference {
+	byName := func(options []ClientOpt) map[string][]ClientOpt {
+		m := make(map[string][]ClientOpt)
+		for _, option := range options {
+			name := strings.ToUpper(option.Name)
+			m[name] = append(m[name], option)
+		}
+		return m
+	}
+
+	fromOpts, otherOpts := byName(from), byName(other)
+
+	names := make(map[string]bool)
+	for name := range fromOpts {
+		names[name] = true
+	}
+	for name := range otherOpts {
+		names[name] = true
+	}
+
+	var diffs []OptionDifference
+	for name := range names {
+		if !sameClientOpts(fromOpts[name], otherOpts[name]) {
+			diffs = append(diffs, OptionDifference{Name: name, From: fromOpts[name], Other: otherOpts[name]})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Name < diffs[j].Name })
+
+	return diffs
+}
+
+func sameClientOpts(a []ClientOpt, b []ClientOpt) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].Value != b[i].Value || a[i].Force != b[i].Force {
+			return false
+		}
+	}
+	return true
+}
+
+func sortClientOpts(options []ClientOpt) {
+	sort.SliceStable(options, func(i, j int) bool {
+		if !strings.EqualFold(options[i].Name, options[j].Name) {
+			return strings.ToUpper(options[i].Name) < strings.ToUpper(options[j].Name)
+		}
+		return options[i].Sequence < options[j].Sequence
+	})
+}
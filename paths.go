@@ -0,0 +1,78 @@
+package gospoc
+
+import (
+	"context"
+	"net/http"
+)
+
+// Paths is an interface for interacting with
+// IBM Spectrum Protect paths to drives and libraries
+type Paths interface {
+	List(ctx context.Context, serverName string) ([]Path, *http.Response, error)
+	SetOnline(ctx context.Context, serverName string, path *Path, online bool) (*http.Response, error)
+}
+
+// PathsOp handles communication with the path related methods of the
+// IBM Spectrum Protect Operations Center REST API
+type PathsOp struct {
+	client *Client
+}
+
+// Path contains the elements that make up a path from a server or data mover to a device
+type Path struct {
+	Source     string `spoc:"SOURCE_NAME"`
+	SourceType string `spoc:"SOURCE_TYPE,nullzero"`
+
+	// Destination is a drive or library, and Library the library of a drive
+	Destination     string `spoc:"DESTINATION_NAME"`
+	DestinationType string `spoc:"DESTINATION_TYPE,nullzero"`
+	Library         string `spoc:"LIBRARY_NAME,nullzero"`
+
+	Device    string `spoc:"DEVICE,nullzero"`
+	Directory string `spoc:"DIRECTORY,nullzero"`
+	Online    bool   `spoc:"ONLINE,nullzero"`
+}
+
+// List all paths of a backup server
+func (s *PathsOp) List(ctx context.Context, serverName string) ([]Path, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	var paths []Path
+	resp, err := s.client.CLI.Query(ctx, serverName, selectFrom("PATHS"), &paths)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return paths, resp, err
+}
+
+// SetOnline makes a path available to the server, or takes it offline. The path is
+// identified by its source, destination, their types and its library.
+func (s *PathsOp) SetOnline(ctx context.Context, serverName string, path *Path, online bool) (*http.Response, error) {
+	if path == nil {
+		return nil, NewArgError("path", "cannot be nil")
+	}
+
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if path.Source == "" || path.Destination == "" {
+		return nil, NewArgError("path", "must name the source and destination")
+	}
+
+	if path.SourceType == "" || path.DestinationType == "" {
+		return nil, NewArgError("path", "must set the source and destination types")
+	}
+
+	cmd := newCommand("UPDATE", "PATH").arg(path.Source).arg(path.Destination).
+		param("SRCTYPE", path.SourceType).
+		param("DESTTYPE", path.DestinationType).
+		param("LIBRARY", path.Library).
+		paramBool("ONLINE", &online)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
@@ -0,0 +1,486 @@
+package gospoc
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Copy group types
+const (
+	CopyGroupBackup  = "BACKUP"
+	CopyGroupArchive = "ARCHIVE"
+)
+
+// Policies is an interface for interacting with the policy sets, management
+// classes and copy groups of IBM Spectrum Protect backup domains
+type Policies interface {
+	ActivatePolicySet(ctx context.Context, serverName string, domainName string, policySet string) (*http.Response, error)
+	AssignDefaultMgmtClass(ctx context.Context, serverName string, domainName string, policySet string, mgmtClass string) (*http.Response, error)
+	CreateArchiveCopyGroup(ctx context.Context, serverName string, createRequest *ArchiveCopyGroupRequest) (*http.Response, error)
+	CreateBackupCopyGroup(ctx context.Context, serverName string, createRequest *BackupCopyGroupRequest) (*http.Response, error)
+	CreateMgmtClass(ctx context.Context, serverName string, createRequest *MgmtClassRequest) (*http.Response, error)
+	CreatePolicySet(ctx context.Context, serverName string, createRequest *PolicySetRequest) (*http.Response, error)
+	GetMgmtClass(ctx context.Context, serverName string, domainName string, policySet string, mgmtClass string) (*MgmtClass, *http.Response, error)
+	GetPolicySet(ctx context.Context, serverName string, domainName string, policySet string) (*PolicySet, *http.Response, error)
+	ListArchiveCopyGroups(ctx context.Context, serverName string, domainName string, policySet string) ([]ArchiveCopyGroup, *http.Response, error)
+	ListBackupCopyGroups(ctx context.Context, serverName string, domainName string, policySet string) ([]BackupCopyGroup, *http.Response, error)
+	ListMgmtClasses(ctx context.Context, serverName string, domainName string, policySet string) ([]MgmtClass, *http.Response, error)
+	ListPolicySets(ctx context.Context, serverName string, domainName string) ([]PolicySet, *http.Response, error)
+	UpdateArchiveCopyGroup(ctx context.Context, serverName string, update *ArchiveCopyGroupRequest) (*http.Response, error)
+	UpdateBackupCopyGroup(ctx context.Context, serverName string, update *BackupCopyGroupRequest) (*http.Response, error)
+	UpdateMgmtClass(ctx context.Context, serverName string, update *MgmtClassRequest) (*http.Response, error)
+	UpdatePolicySet(ctx context.Context, serverName string, update *PolicySetRequest) (*http.Response, error)
+	ValidatePolicySet(ctx context.Context, serverName string, domainName string, policySet string) (*CommandResult, *http.Response, error)
+}
+
+// PoliciesOp handles communication with the policy related methods of the
+// IBM Spectrum Protect Operations Center REST API
+type PoliciesOp struct {
+	client *Client
+}
+
+// PolicySet contains the elements that make up a policy set
+type PolicySet struct {
+	Domain           string    `spoc:"DOMAIN_NAME"`
+	Name             string    `spoc:"SET_NAME"`
+	DefaultMgmtClass string    `spoc:"DEFMGMTCLASS,nullzero"`
+	Description      string    `spoc:"DESCRIPTION,nullzero"`
+	ChangedBy        string    `spoc:"CHG_ADMIN,nullzero"`
+	ChangedAt        time.Time `spoc:"CHG_TIME,nullzero"`
+	Profile          string    `spoc:"PROFILE,nullzero"`
+}
+
+// MgmtClass contains the elements that make up a management class
+type MgmtClass struct {
+	Domain                string    `spoc:"DOMAIN_NAME"`
+	PolicySet             string    `spoc:"SET_NAME"`
+	Name                  string    `spoc:"CLASS_NAME"`
+	Default               bool      `spoc:"DEFAULTMC,nullzero"`
+	Description           string    `spoc:"DESCRIPTION,nullzero"`
+	SpaceMgmtTechnique    string    `spoc:"SPACEMGTECHNIQUE,nullzero"`
+	AutoMigrateNonUse     int       `spoc:"AUTOMIGNONUSE,nullzero"`
+	MigrationRequiresBkup bool      `spoc:"MIGREQUIRESBKUP,nullzero"`
+	MigrationDestination  string    `spoc:"MIGDESTINATION,nullzero"`
+	ChangedBy             string    `spoc:"CHG_ADMIN,nullzero"`
+	ChangedAt             time.Time `spoc:"CHG_TIME,nullzero"`
+	Profile               string    `spoc:"PROFILE,nullzero"`
+}
+
+// BackupCopyGroup contains the elements that make up a backup copy group.
+// Version and retention values are strings since they can be NOLIMIT.
+type BackupCopyGroup struct {
+	Domain          string    `spoc:"DOMAIN_NAME"`
+	PolicySet       string    `spoc:"SET_NAME"`
+	MgmtClass       string    `spoc:"CLASS_NAME"`
+	Name            string    `spoc:"COPYGROUP_NAME"`
+	VersionsExists  string    `spoc:"VEREXISTS,nullzero"`
+	VersionsDeleted string    `spoc:"VERDELETED,nullzero"`
+	RetainExtra     string    `spoc:"RETEXTRA,nullzero"`
+	RetainOnly      string    `spoc:"RETONLY,nullzero"`
+	Mode            string    `spoc:"MODE,nullzero"`
+	Serialization   string    `spoc:"SERIALIZATION,nullzero"`
+	Frequency       int       `spoc:"FREQUENCY,nullzero"`
+	Destination     string    `spoc:"DESTINATION,nullzero"`
+	TOCDestination  string    `spoc:"TOC_DESTINATION,nullzero"`
+	ChangedBy       string    `spoc:"CHG_ADMIN,nullzero"`
+	ChangedAt       time.Time `spoc:"CHG_TIME,nullzero"`
+	Profile         string    `spoc:"PROFILE,nullzero"`
+}
+
+// ArchiveCopyGroup contains the elements that make up an archive copy group.
+// Retention values are strings since they can be NOLIMIT.
+type ArchiveCopyGroup struct {
+	Domain        string    `spoc:"DOMAIN_NAME"`
+	PolicySet     string    `spoc:"SET_NAME"`
+	MgmtClass     string    `spoc:"CLASS_NAME"`
+	Name          string    `spoc:"COPYGROUP_NAME"`
+	RetainVersion string    `spoc:"RETVER,nullzero"`
+	RetainInit    string    `spoc:"RETINIT,nullzero"`
+	RetainMin     string    `spoc:"RETMIN,nullzero"`
+	Serialization string    `spoc:"SERIALIZATION,nullzero"`
+	Destination   string    `spoc:"DESTINATION,nullzero"`
+	ChangedBy     string    `spoc:"CHG_ADMIN,nullzero"`
+	ChangedAt     time.Time `spoc:"CHG_TIME,nullzero"`
+	Profile       string    `spoc:"PROFILE,nullzero"`
+}
+
+// PolicySetRequest represents a request to define or update a policy set
+type PolicySetRequest struct {
+	Domain      string
+	Name        string
+	Description string
+}
+
+// MgmtClassRequest represents a request to define or update a management class.
+// Empty fields are left at their defaults on create and unchanged on update.
+type MgmtClassRequest struct {
+	Domain                string
+	PolicySet             string
+	Name                  string
+	Description           string
+	SpaceMgmtTechnique    string
+	AutoMigrateNonUse     *int
+	MigrationRequiresBkup *bool
+	MigrationDestination  string
+}
+
+// BackupCopyGroupRequest represents a request to define or update the backup copy group
+// of a management class. Version and retention values accept NOLIMIT.
+type BackupCopyGroupRequest struct {
+	Domain          string
+	PolicySet       string
+	MgmtClass       string
+	Destination     string
+	VersionsExists  string
+	VersionsDeleted string
+	RetainExtra     string
+	RetainOnly      string
+	Mode            string
+	Serialization   string
+	Frequency       *int
+	TOCDestination  string
+}
+
+// ArchiveCopyGroupRequest represents a request to define or update the archive copy group
+// of a management class. RetainVersion accepts NOLIMIT.
+type ArchiveCopyGroupRequest struct {
+	Domain        string
+	PolicySet     string
+	MgmtClass     string
+	Destination   string
+	RetainVersion string
+	RetainInit    string
+	RetainMin     *int
+	Serialization string
+}
+
+// ListPolicySets lists the policy sets of a backup domain, or of every domain when domainName is empty
+func (s *PoliciesOp) ListPolicySets(ctx context.Context, serverName string, domainName string) ([]PolicySet, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	var sets []PolicySet
+	query := selectFrom("POLICYSETS", sqlName("DOMAIN_NAME", domainName))
+	resp, err := s.client.CLI.Query(ctx, serverName, query, &sets)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sets, resp, err
+}
+
+// GetPolicySet gets the details of a specific policy set
+func (s *PoliciesOp) GetPolicySet(ctx context.Context, serverName string, domainName string, policySet string) (*PolicySet, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if domainName == "" {
+		return nil, nil, NewArgError("domainName", "cannot be empty")
+	}
+
+	if policySet == "" {
+		return nil, nil, NewArgError("policySet", "cannot be empty")
+	}
+
+	var sets []PolicySet
+	query := selectFrom("POLICYSETS", sqlName("DOMAIN_NAME", domainName), sqlName("SET_NAME", policySet))
+	resp, err := s.client.CLI.Query(ctx, serverName, query, &sets)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(sets) == 0 {
+		return nil, resp, notFound("policy set "+domainName+"/"+policySet, serverName)
+	}
+
+	return &sets[0], resp, err
+}
+
+// CreatePolicySet defines a new policy set
+func (s *PoliciesOp) CreatePolicySet(ctx context.Context, serverName string, createRequest *PolicySetRequest) (*http.Response, error) {
+	return s.policySetCommand(ctx, "DEFINE", serverName, createRequest, "createRequest")
+}
+
+// UpdatePolicySet updates the description of a policy set
+func (s *PoliciesOp) UpdatePolicySet(ctx context.Context, serverName string, update *PolicySetRequest) (*http.Response, error) {
+	return s.policySetCommand(ctx, "UPDATE", serverName, update, "update")
+}
+
+func (s *PoliciesOp) policySetCommand(ctx context.Context, verb string, serverName string, r *PolicySetRequest, argName string) (*http.Response, error) {
+	if r == nil {
+		return nil, NewArgError(argName, "cannot be nil")
+	}
+
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if r.Domain == "" || r.Name == "" {
+		return nil, NewArgError(argName, "must name the domain and policy set")
+	}
+
+	cmd := newCommand(verb, "POLICYSET").arg(r.Domain).arg(r.Name).
+		param("DESCRIPTION", r.Description)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// ValidatePolicySet validates a policy set. The result contains the warnings reported by the server.
+func (s *PoliciesOp) ValidatePolicySet(ctx context.Context, serverName string, domainName string, policySet string) (*CommandResult, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if domainName == "" {
+		return nil, nil, NewArgError("domainName", "cannot be empty")
+	}
+
+	if policySet == "" {
+		return nil, nil, NewArgError("policySet", "cannot be empty")
+	}
+
+	cmd := newCommand("VALIDATE", "POLICYSET").arg(domainName).arg(policySet)
+	return s.client.runCommand(ctx, serverName, cmd)
+}
+
+// ActivatePolicySet makes a policy set the active policy set of its domain
+func (s *PoliciesOp) ActivatePolicySet(ctx context.Context, serverName string, domainName string, policySet string) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if domainName == "" {
+		return nil, NewArgError("domainName", "cannot be empty")
+	}
+
+	if policySet == "" {
+		return nil, NewArgError("policySet", "cannot be empty")
+	}
+
+	cmd := newCommand("ACTIVATE", "POLICYSET").arg(domainName).arg(policySet)
+	_, resp, err := s.client.runConfirmedCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// AssignDefaultMgmtClass makes mgmtClass the default management class of a policy set
+func (s *PoliciesOp) AssignDefaultMgmtClass(ctx context.Context, serverName string, domainName string, policySet string, mgmtClass string) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if domainName == "" {
+		return nil, NewArgError("domainName", "cannot be empty")
+	}
+
+	if policySet == "" {
+		return nil, NewArgError("policySet", "cannot be empty")
+	}
+
+	if mgmtClass == "" {
+		return nil, NewArgError("mgmtClass", "cannot be empty")
+	}
+
+	cmd := newCommand("ASSIGN", "DEFMGMTCLASS").arg(domainName).arg(policySet).arg(mgmtClass)
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// ListMgmtClasses lists the management classes of a domain, optionally limited to one policy set
+func (s *PoliciesOp) ListMgmtClasses(ctx context.Context, serverName string, domainName string, policySet string) ([]MgmtClass, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	var classes []MgmtClass
+	query := selectFrom("MGMTCLASSES", sqlName("DOMAIN_NAME", domainName), sqlName("SET_NAME", policySet))
+	resp, err := s.client.CLI.Query(ctx, serverName, query, &classes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return classes, resp, err
+}
+
+// GetMgmtClass gets the details of a specific management class
+func (s *PoliciesOp) GetMgmtClass(ctx context.Context, serverName string, domainName string, policySet string, mgmtClass string) (*MgmtClass, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if domainName == "" {
+		return nil, nil, NewArgError("domainName", "cannot be empty")
+	}
+
+	if policySet == "" {
+		return nil, nil, NewArgError("policySet", "cannot be empty")
+	}
+
+	if mgmtClass == "" {
+		return nil, nil, NewArgError("mgmtClass", "cannot be empty")
+	}
+
+	var classes []MgmtClass
+	query := selectFrom("MGMTCLASSES", sqlName("DOMAIN_NAME", domainName), sqlName("SET_NAME", policySet), sqlName("CLASS_NAME", mgmtClass))
+	resp, err := s.client.CLI.Query(ctx, serverName, query, &classes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(classes) == 0 {
+		return nil, resp, notFound("management class "+domainName+"/"+policySet+"/"+mgmtClass, serverName)
+	}
+
+	return &classes[0], resp, err
+}
+
+// CreateMgmtClass defines a new management class
+func (s *PoliciesOp) CreateMgmtClass(ctx context.Context, serverName string, createRequest *MgmtClassRequest) (*http.Response, error) {
+	return s.mgmtClassCommand(ctx, "DEFINE", serverName, createRequest, "createRequest")
+}
+
+// UpdateMgmtClass updates the settings of a management class
+func (s *PoliciesOp) UpdateMgmtClass(ctx context.Context, serverName string, update *MgmtClassRequest) (*http.Response, error) {
+	return s.mgmtClassCommand(ctx, "UPDATE", serverName, update, "update")
+}
+
+func (s *PoliciesOp) mgmtClassCommand(ctx context.Context, verb string, serverName string, r *MgmtClassRequest, argName string) (*http.Response, error) {
+	if r == nil {
+		return nil, NewArgError(argName, "cannot be nil")
+	}
+
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if r.Domain == "" || r.PolicySet == "" || r.Name == "" {
+		return nil, NewArgError(argName, "must name the domain, policy set and management class")
+	}
+
+	cmd := newCommand(verb, "MGMTCLASS").arg(r.Domain).arg(r.PolicySet).arg(r.Name).
+		param("DESCRIPTION", r.Description).
+		param("SPACEMGTECHNIQUE", r.SpaceMgmtTechnique).
+		paramInt("AUTOMIGNONUSE", r.AutoMigrateNonUse).
+		paramBool("MIGREQUIRESBKUP", r.MigrationRequiresBkup).
+		param("MIGDESTINATION", r.MigrationDestination)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// ListBackupCopyGroups lists the backup copy groups of a domain, optionally limited to one policy set
+func (s *PoliciesOp) ListBackupCopyGroups(ctx context.Context, serverName string, domainName string, policySet string) ([]BackupCopyGroup, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	var groups []BackupCopyGroup
+	query := selectFrom("BU_COPYGROUPS", sqlName("DOMAIN_NAME", domainName), sqlName("SET_NAME", policySet))
+	resp, err := s.client.CLI.Query(ctx, serverName, query, &groups)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return groups, resp, err
+}
+
+// CreateBackupCopyGroup defines the backup copy group of a management class
+func (s *PoliciesOp) CreateBackupCopyGroup(ctx context.Context, serverName string, createRequest *BackupCopyGroupRequest) (*http.Response, error) {
+	return s.backupCopyGroupCommand(ctx, "DEFINE", serverName, createRequest, "createRequest")
+}
+
+// UpdateBackupCopyGroup updates the backup copy group of a management class
+func (s *PoliciesOp) UpdateBackupCopyGroup(ctx context.Context, serverName string, update *BackupCopyGroupRequest) (*http.Response, error) {
+	return s.backupCopyGroupCommand(ctx, "UPDATE", serverName, update, "update")
+}
+
+func (s *PoliciesOp) backupCopyGroupCommand(ctx context.Context, verb string, serverName string, r *BackupCopyGroupRequest, argName string) (*http.Response, error) {
+	if r == nil {
+		return nil, NewArgError(argName, "cannot be nil")
+	}
+
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if r.Domain == "" || r.PolicySet == "" || r.MgmtClass == "" {
+		return nil, NewArgError(argName, "must name the domain, policy set and management class")
+	}
+
+	if verb == "DEFINE" && r.Destination == "" {
+		return nil, NewArgError(argName+".Destination", "cannot be empty")
+	}
+
+	cmd := newCommand(verb, "COPYGROUP").arg(r.Domain).arg(r.PolicySet).arg(r.MgmtClass).arg("STANDARD").
+		param("TYPE", CopyGroupBackup).
+		param("DESTINATION", r.Destination).
+		param("VEREXISTS", r.VersionsExists).
+		param("VERDELETED", r.VersionsDeleted).
+		param("RETEXTRA", r.RetainExtra).
+		param("RETONLY", r.RetainOnly).
+		param("MODE", r.Mode).
+		param("SERIALIZATION", r.Serialization).
+		paramInt("FREQUENCY", r.Frequency).
+		param("TOCDESTINATION", r.TOCDestination)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// ListArchiveCopyGroups lists the archive copy groups of a domain, optionally limited to one policy set
+func (s *PoliciesOp) ListArchiveCopyGroups(ctx context.Context, serverName string, domainName string, policySet string) ([]ArchiveCopyGroup, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	var groups []ArchiveCopyGroup
+	query := selectFrom("AR_COPYGROUPS", sqlName("DOMAIN_NAME", domainName), sqlName("SET_NAME", policySet))
+	resp, err := s.client.CLI.Query(ctx, serverName, query, &groups)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return groups, resp, err
+}
+
+// CreateArchiveCopyGroup defines the archive copy group of a management class
+func (s *PoliciesOp) CreateArchiveCopyGroup(ctx context.Context, serverName string, createRequest *ArchiveCopyGroupRequest) (*http.Response, error) {
+	return s.archiveCopyGroupCommand(ctx, "DEFINE", serverName, createRequest, "createRequest")
+}
+
+// UpdateArchiveCopyGroup updates the archive copy group of a management class
+func (s *PoliciesOp) UpdateArchiveCopyGroup(ctx context.Context, serverName string, update *ArchiveCopyGroupRequest) (*http.Response, error) {
+	return s.archiveCopyGroupCommand(ctx, "UPDATE", serverName, update, "update")
+}
+
+func (s *PoliciesOp) archiveCopyGroupCommand(ctx context.Context, verb string, serverName string, r *ArchiveCopyGroupRequest, argName string) (*http.Response, error) {
+	if r == nil {
+		return nil, NewArgError(argName, "cannot be nil")
+	}
+
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if r.Domain == "" || r.PolicySet == "" || r.MgmtClass == "" {
+		return nil, NewArgError(argName, "must name the domain, policy set and management class")
+	}
+
+	if verb == "DEFINE" && r.Destination == "" {
+		return nil, NewArgError(argName+".Destination", "cannot be empty")
+	}
+
+	cmd := newCommand(verb, "COPYGROUP").arg(r.Domain).arg(r.PolicySet).arg(r.MgmtClass).arg("STANDARD").
+		param("TYPE", CopyGroupArchive).
+		param("DESTINATION", r.Destination).
+		param("RETVER", r.RetainVersion).
+		param("RETINIT", r.RetainInit).
+		paramInt("RETMIN", r.RetainMin).
+		param("SERIALIZATION", r.Serialization)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
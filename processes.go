@@ -0,0 +1,89 @@
+package gospoc
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Processes is an interface for interacting with
+// IBM Spectrum Protect server processes
+type Processes interface {
+	Cancel(ctx context.Context, serverName string, processNumber int64) (*http.Response, error)
+	Get(ctx context.Context, serverName string, processNumber int64) (*Process, *http.Response, error)
+	List(ctx context.Context, serverName string) ([]Process, *http.Response, error)
+	Run(ctx context.Context, serverName string, command string, opts *ProcessWaitOptions) (*ProcessResult, *http.Response, error)
+	Wait(ctx context.Context, serverName string, processNumber int64, opts *ProcessWaitOptions) (*ProcessResult, *http.Response, error)
+}
+
+// ProcessesOp handles communication with the server process related methods of the
+// IBM Spectrum Protect Operations Center REST API
+type ProcessesOp struct {
+	client *Client
+}
+
+// Process contains the elements that make up a running server process
+type Process struct {
+	Number         int64     `spoc:"PROCESS_NUM"`
+	Description    string    `spoc:"PROCESS,nullzero"`
+	StartTime      time.Time `spoc:"START_TIME,nullzero"`
+	FilesProcessed int64     `spoc:"FILES_PROCESSED,nullzero"`
+	BytesProcessed ByteSize  `spoc:"BYTES_PROCESSED,nullzero"`
+	Status         string    `spoc:"STATUS,nullzero"`
+}
+
+// List the running processes of a backup server
+func (s *ProcessesOp) List(ctx context.Context, serverName string) ([]Process, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	var processes []Process
+	resp, err := s.client.CLI.Query(ctx, serverName, selectFrom("PROCESSES"), &processes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return processes, resp, err
+}
+
+// Get a running server process. The error wraps ErrNotFound once the process has ended.
+func (s *ProcessesOp) Get(ctx context.Context, serverName string, processNumber int64) (*Process, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if processNumber <= 0 {
+		return nil, nil, NewArgError("processNumber", "must be positive")
+	}
+
+	var processes []Process
+	query := selectFrom("PROCESSES", "PROCESS_NUM="+strconv.FormatInt(processNumber, 10))
+	resp, err := s.client.CLI.Query(ctx, serverName, query, &processes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(processes) == 0 {
+		return nil, resp, notFound("process "+strconv.FormatInt(processNumber, 10), serverName)
+	}
+
+	return &processes[0], resp, err
+}
+
+// Cancel a running server process
+func (s *ProcessesOp) Cancel(ctx context.Context, serverName string, processNumber int64) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if processNumber <= 0 {
+		return nil, NewArgError("processNumber", "must be positive")
+	}
+
+	cmd := newCommand("CANCEL", "PROCESS", strconv.FormatInt(processNumber, 10))
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
@@ -0,0 +1,222 @@
+package gospoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// ErrNoProcess is returned by Processes.Run when the command did not start a background process
+var ErrNoProcess = errors.New("command did not start a background process")
+
+const (
+	defaultProcessPollInterval = 10 * time.Second
+
+	// processLogLookback bounds the activity log search of Wait for a process
+	// that ended before its start time could be read
+	processLogLookback = 24 * time.Hour
+
+	// processCompletionPolls is how many times the activity log is read for the
+	// completion message of a process that ended
+	processCompletionPolls = 3
+
+	msgProcessStarted   = "ANR0984I"
+	msgProcessCompleted = "ANR0985I"
+)
+
+var (
+	processNumberRe     = regexp.MustCompile(`(?i)\bProcess\s+([0-9]+)\b`)
+	processCompletionRe = regexp.MustCompile(`(?i)completion\s+state\s+(?:of\s+)?([A-Z]+)`)
+)
+
+// ProcessWaitOptions controls how a server process is polled until it ends
+type ProcessWaitOptions struct {
+	// PollInterval is the time between polls. It defaults to 10 seconds.
+	PollInterval time.Duration
+
+	// Progress is called with the state of the process after every poll
+	Progress func(*Process)
+}
+
+// ProcessResult is the outcome of a server process that ended
+type ProcessResult struct {
+	Server      string
+	Number      int64
+	Description string
+	StartTime   time.Time
+	EndTime     time.Time
+
+	// Completion is the completion state of the process, such as SUCCESS or FAILURE
+	Completion string
+
+	// Messages are the activity log messages of the process, oldest first
+	Messages []CommandMessage
+}
+
+// Succeeded reports whether the process completed successfully
+func (r *ProcessResult) Succeeded() bool {
+	return r.Completion == "SUCCESS"
+}
+
+// Run issues a command that starts a background process, such as BACKUP DB or
+// MIGRATE STGPOOL, then waits for the process to end. The error wraps ErrNoProcess
+// when the command did not start a process.
+func (s *ProcessesOp) Run(ctx context.Context, serverName string, command string, opts *ProcessWaitOptions) (*ProcessResult, *http.Response, error) {
+	// Messages logged before the command was issued belong to older processes
+	issued := time.Now()
+
+	result, resp, err := s.client.CLI.IssueCommand(ctx, serverName, command)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	processNumber, ok := startedProcess(result)
+	if !ok {
+		return nil, resp, fmt.Errorf("Unable to wait for %q on server %s: %w", command, serverName, ErrNoProcess)
+	}
+
+	return s.wait(ctx, serverName, processNumber, opts, issued)
+}
+
+// Wait polls a server process until it ends, then returns its completion state from
+// the activity log. Waiting stops with the error of ctx when ctx is done.
+func (s *ProcessesOp) Wait(ctx context.Context, serverName string, processNumber int64, opts *ProcessWaitOptions) (*ProcessResult, *http.Response, error) {
+	return s.wait(ctx, serverName, processNumber, opts, time.Now().Add(-processLogLookback))
+}
+
+// wait polls a server process until it ends. The activity log is searched from the
+// start time of the process or, when it ended before its first poll, from since.
+func (s *ProcessesOp) wait(ctx context.Context, serverName string, processNumber int64, opts *ProcessWaitOptions, since time.Time) (*ProcessResult, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if processNumber <= 0 {
+		return nil, nil, NewArgError("processNumber", "must be positive")
+	}
+
+	if opts == nil {
+		opts = new(ProcessWaitOptions)
+	}
+
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultProcessPollInterval
+	}
+
+	res := &ProcessResult{Server: serverName, Number: processNumber}
+
+	for {
+		process, resp, err := s.Get(ctx, serverName, processNumber)
+		if errors.Is(err, ErrNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, resp, err
+		}
+
+		if res.StartTime.IsZero() {
+			res.Description = process.Description
+			res.StartTime = process.StartTime
+			if !process.StartTime.IsZero() {
+				since = process.StartTime
+			}
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(process)
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return nil, resp, err
+		}
+	}
+
+	// The completion message can reach the activity log after the process is gone
+	for poll := 1; ; poll++ {
+		found, resp, err := s.completion(ctx, res, since)
+		if err != nil || found {
+			return res, resp, err
+		}
+
+		if poll == processCompletionPolls {
+			return res, resp, notFound(fmt.Sprintf("completion message of process %d", processNumber), serverName)
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return nil, resp, err
+		}
+	}
+}
+
+// completion fills res from the activity log messages of its process and reports
+// whether the completion message was found
+func (s *ProcessesOp) completion(ctx context.Context, res *ProcessResult, since time.Time) (bool, *http.Response, error) {
+	filter := &ActivityLogFilter{Begin: since, Process: res.Number}
+	entries, resp, err := s.client.ActivityLog.Query(ctx, res.Server, filter)
+	if err != nil {
+		return false, resp, err
+	}
+
+	// Process numbers restart with the server, so only the messages logged after
+	// the previous completion of the same number belong to this process
+	first, last := 0, -1
+	for i, entry := range entries {
+		if entry.MessageNumber != msgProcessCompleted {
+			continue
+		}
+		if last >= 0 {
+			first = last + 1
+		}
+		last = i
+	}
+
+	if last < 0 {
+		return false, resp, nil
+	}
+
+	res.Messages = nil
+	for _, entry := range entries[first : last+1] {
+		res.Messages = append(res.Messages, parseMessage(entry.Message))
+	}
+
+	res.EndTime = entries[last].Time
+	if m := processCompletionRe.FindStringSubmatch(entries[last].Message); m != nil {
+		res.Completion = m[1]
+	}
+
+	return true, resp, nil
+}
+
+// startedProcess returns the number of the background process started by a command
+func startedProcess(result *CommandResult) (int64, bool) {
+	m, ok := result.Message(msgProcessStarted)
+	if !ok {
+		return 0, false
+	}
+
+	match := processNumberRe.FindStringSubmatch(m.Text)
+	if match == nil {
+		return 0, false
+	}
+
+	n, err := strconv.ParseInt(match[1], 10, 64)
+	return n, err == nil
+}
+
+// sleepContext waits for d, or returns the error of ctx when it is done first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,385 @@
+package gospoc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query runs a SELECT statement and scans the result set into dest. See CommandResult.Scan
+// for the supported destinations. A SELECT that matches no rows is not an error.
+func (s *CLIOp) Query(ctx context.Context, serverName string, query string, dest interface{}) (*http.Response, error) {
+	if dest == nil {
+		return nil, NewArgError("dest", "cannot be nil")
+	}
+
+	result, resp, err := s.selectRows(ctx, serverName, query)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, result.scan(dest, false)
+}
+
+// selectRows runs a SELECT statement, returning an empty result when no rows match
+func (s *CLIOp) selectRows(ctx context.Context, serverName string, query string) (*CommandResult, *http.Response, error) {
+	if !isSelect(query) {
+		return nil, nil, NewArgError("query", "must be a single SELECT statement")
+	}
+
+	// SELECT statements do not change anything, so they are safe to retry
+	result, resp, err := s.IssueCommand(WithIdempotent(ctx), serverName, query)
+	if err != nil {
+		var cmdErr *CommandError
+		if !errors.As(err, &cmdErr) || cmdErr.ReturnCode != rcNotFound {
+			return nil, resp, err
+		}
+		result.Rows = nil
+	}
+
+	return result, resp, nil
+}
+
+// isSelect reports whether query is a single SELECT statement
+func isSelect(query string) bool {
+	q := strings.TrimSpace(query)
+	q = strings.TrimSuffix(q, ";")
+
+	var quote rune
+	for _, r := range q {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == ';':
+			return false
+		}
+	}
+	if quote != 0 {
+		return false
+	}
+
+	fields := strings.Fields(q)
+	return len(fields) > 0 && strings.EqualFold(fields[0], "SELECT")
+}
+
+// Scan stores the rows of the result in dest, which must be a pointer to a slice of
+// structs or of pointers to structs, or a pointer to a struct that receives the first
+// row. A pointer to a struct returns an error matching ErrNotFound when there are no rows.
+//
+// Columns are matched to fields with the spoc tag, for example `spoc:"NODE_NAME"`, or
+// else by field name ignoring case and underscores. Columns without a matching field are
+// ignored. Values are converted the way database/sql converts them: NULL can only be
+// stored in pointers, interfaces and sql.Scanner implementations unless the tag has the
+// nullzero option, which leaves the field at its zero value instead.
+func (r *CommandResult) Scan(dest interface{}) error {
+	return r.scan(dest, true)
+}
+
+func (r *CommandResult) scan(dest interface{}, singleNotFound bool) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return NewArgError("dest", "must be a non-nil pointer")
+	}
+	v = v.Elem()
+
+	switch {
+	case v.Kind() == reflect.Struct:
+		if len(r.Rows) == 0 {
+			if !singleNotFound {
+				return nil
+			}
+			return fmt.Errorf("Command %q returned no rows: %w", r.Command, ErrNotFound)
+		}
+		return scanRow(r.Rows[0], r.Columns, structFields(v.Type()), v)
+	case v.Kind() == reflect.Slice:
+		elemType := v.Type().Elem()
+		isPtr := elemType.Kind() == reflect.Ptr
+		if isPtr {
+			elemType = elemType.Elem()
+		}
+		if elemType.Kind() != reflect.Struct {
+			return NewArgError("dest", "must point to a slice of structs")
+		}
+
+		fields := structFields(elemType)
+		slice := reflect.MakeSlice(v.Type(), 0, len(r.Rows))
+		for _, row := range r.Rows {
+			elem := reflect.New(elemType)
+			if err := scanRow(row, r.Columns, fields, elem.Elem()); err != nil {
+				return err
+			}
+			if isPtr {
+				slice = reflect.Append(slice, elem)
+			} else {
+				slice = reflect.Append(slice, elem.Elem())
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+
+	return NewArgError("dest", "must point to a struct or a slice of structs")
+}
+
+type scanField struct {
+	index    []int
+	nullZero bool
+}
+
+// structFields maps normalized column names to the fields of t. The fields of
+// embedded structs without a spoc tag are promoted.
+func structFields(t reflect.Type) map[string]scanField {
+	fields := make(map[string]scanField)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+
+		if _, tagged := f.Tag.Lookup("spoc"); f.Anonymous && !tagged && f.Type.Kind() == reflect.Struct && f.Type != timeType {
+			for name, embedded := range structFields(f.Type) {
+				if _, ok := fields[name]; !ok {
+					embedded.index = append([]int{i}, embedded.index...)
+					fields[name] = embedded
+				}
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+
+		name := f.Name
+		var nullZero bool
+		if tag, ok := f.Tag.Lookup("spoc"); ok {
+			if tag == "-" {
+				continue
+			}
+			parts := strings.Split(tag, ",")
+			if parts[0] != "" {
+				name = parts[0]
+			}
+			for _, opt := range parts[1:] {
+				if opt == "nullzero" {
+					nullZero = true
+				}
+			}
+		}
+
+		fields[normalizeColumn(name)] = scanField{index: f.Index, nullZero: nullZero}
+	}
+	return fields
+}
+
+func normalizeColumn(name string) string {
+	return strings.ToUpper(strings.NewReplacer("_", "", " ", "").Replace(name))
+}
+
+// scanRow stores the columns of row in v. Columns absent from the row are NULL.
+func scanRow(row CommandRow, columns []string, fields map[string]scanField, v reflect.Value) error {
+	for _, col := range columns {
+		f, ok := fields[normalizeColumn(col)]
+		if !ok {
+			continue
+		}
+
+		value, valid := row[col]
+		if err := convertAssign(v.FieldByIndex(f.index), value, valid, f.nullZero); err != nil {
+			return fmt.Errorf("Unable to scan column %s: %v", col, err)
+		}
+	}
+
+	return nil
+}
+
+var (
+	scannerType  = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType     = reflect.TypeOf(time.Time{})
+	nullTimeType = reflect.TypeOf(sql.NullTime{})
+)
+
+// convertAssign stores value in dst. valid is false for NULL values.
+func convertAssign(dst reflect.Value, value string, valid bool, nullZero bool) error {
+	if dst.CanAddr() && dst.Addr().Type().Implements(scannerType) {
+		scanner := dst.Addr().Interface().(sql.Scanner)
+		if !valid {
+			return scanner.Scan(nil)
+		}
+		if dst.Type() == nullTimeType {
+			t, err := parseTimestamp(value)
+			if err != nil {
+				return err
+			}
+			return scanner.Scan(t)
+		}
+		return scanner.Scan(value)
+	}
+
+	// Text output shows NULL as an empty value
+	if valid && strings.TrimSpace(value) == "" && indirectType(dst.Type()).Kind() != reflect.String {
+		valid = false
+	}
+
+	switch dst.Kind() {
+	case reflect.Ptr:
+		if !valid {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		elem := reflect.New(dst.Type().Elem())
+		if err := convertAssign(elem.Elem(), value, true, false); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	case reflect.Interface:
+		if !valid {
+			dst.Set(reflect.Zero(dst.Type()))
+		} else {
+			dst.Set(reflect.ValueOf(value))
+		}
+		return nil
+	}
+
+	if !valid {
+		if nullZero {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		return fmt.Errorf("converting NULL to %s is unsupported", dst.Type())
+	}
+
+	if dst.Type() == timeType {
+		t, err := parseTimestamp(value)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(value)
+		return nil
+	case reflect.Bool:
+		b, err := parseBool(value)
+		if err != nil {
+			return err
+		}
+		dst.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(numericText(value), 10, dst.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("converting %q to %s: %v", value, dst.Type(), err)
+		}
+		dst.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(numericText(value), 10, dst.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("converting %q to %s: %v", value, dst.Type(), err)
+		}
+		dst.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(numericText(value), dst.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("converting %q to %s: %v", value, dst.Type(), err)
+		}
+		dst.SetFloat(n)
+		return nil
+	}
+
+	return fmt.Errorf("unsupported destination type %s", dst.Type())
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// numericText removes the thousands separators used in command output
+func numericText(value string) string {
+	return strings.Replace(strings.TrimSpace(value), ",", "", -1)
+}
+
+func parseBool(value string) (bool, error) {
+	switch strings.ToUpper(strings.TrimSpace(value)) {
+	case "YES", "Y", "TRUE", "T", "ON", "1":
+		return true, nil
+	case "NO", "N", "FALSE", "F", "OFF", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("converting %q to bool: invalid syntax", value)
+}
+
+// Timestamp layouts used by IBM Spectrum Protect servers
+var timestampLayouts = []string{
+	"2006-01-02 15:04:05.000000",
+	"2006-01-02 15:04:05",
+	"2006-01-02-15.04.05.000000",
+	"2006-01-02T15:04:05Z07:00",
+	"01/02/2006 15:04:05",
+	"01/02/06 15:04:05",
+	"2006-01-02",
+	"01/02/2006",
+}
+
+// parseTimestamp parses a server timestamp in the local time zone
+func parseTimestamp(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range timestampLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("converting %q to time.Time: unknown timestamp format", value)
+}
+
+// selectFrom builds a SELECT statement on table, joining the conditions that are not empty
+func selectFrom(table string, conditions ...string) string {
+	query := "SELECT * FROM " + table
+
+	var where []string
+	for _, cond := range conditions {
+		if cond != "" {
+			where = append(where, cond)
+		}
+	}
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	return query
+}
+
+// sqlName compares column to an object name, which the server stores in upper case.
+// An empty name matches everything.
+func sqlName(column string, name string) string {
+	if name == "" {
+		return ""
+	}
+	return column + "=" + quoteSQL(strings.ToUpper(name))
+}
+
+// sqlTime compares column to a timestamp with op. A zero time matches everything.
+func sqlTime(column string, op string, t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return column + op + "'" + t.In(time.Local).Format("2006-01-02 15:04:05") + "'"
+}
@@ -0,0 +1,166 @@
+package gospoc
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const selectNodesText = `ANR2017I Administrator ADMIN issued command: SELECT NODE_NAME, DOMAIN_NAME, LASTACC_TIME, LOCKED, NUM_FILES, TCP_ADDRESS FROM NODES
+
+NODE_NAME           DOMAIN_NAME     LASTACC_TIME                  LOCKED     NUM_FILES     TCP_ADDRESS
+------------------  --------------  --------------------------  --------  ------------  ------------
+CLIENT1             STANDARD        2026-10-14 09:12:01.000000  NO           1,234,567  10.0.0.1
+CLIENT2             VMWARE                                      YES                  0
+`
+
+type nodeAccess struct {
+	Name       string `spoc:"NODE_NAME"`
+	DomainName string
+	LastAccess time.Time `spoc:"LASTACC_TIME,nullzero"`
+	Locked     bool
+	NumFiles   int64   `spoc:"NUM_FILES,nullzero"`
+	TCPAddress *string `spoc:"TCP_ADDRESS"`
+}
+
+func TestCommandResult_ScanText(t *testing.T) {
+	result := parseCommandText(selectNodesText)
+
+	var nodes []nodeAccess
+	if err := result.Scan(&nodes); err != nil {
+		t.Fatalf("Scan returned error: %v", err)
+	}
+
+	// Text output cannot tell an empty string from NULL
+	address, empty := "10.0.0.1", ""
+	expected := []nodeAccess{
+		{Name: "CLIENT1", DomainName: "STANDARD", LastAccess: time.Date(2026, 10, 14, 9, 12, 1, 0, time.Local), NumFiles: 1234567, TCPAddress: &address},
+		{Name: "CLIENT2", DomainName: "VMWARE", Locked: true, TCPAddress: &empty},
+	}
+	if !reflect.DeepEqual(nodes, expected) {
+		t.Errorf("Scan returned %+v, expected %+v", nodes, expected)
+	}
+}
+
+func TestCommandResult_ScanJSON(t *testing.T) {
+	result, err := parseCommandResult([]byte(`{"ITEMS":[
+		{"NODE_NAME":"CLIENT1","CONTACT":null,"LASTACC_TIME":"2026-10-14 09:12:01.000000","REG_TIME":null,"PCT_UTIL":"87.5"}
+	],"RC":0}`))
+	if err != nil {
+		t.Fatalf("parseCommandResult returned error: %v", err)
+	}
+
+	type embedded struct {
+		Name string `spoc:"NODE_NAME"`
+	}
+	var row struct {
+		embedded
+		Contact    sql.NullString
+		LastAccess sql.NullTime `spoc:"LASTACC_TIME"`
+		Registered sql.NullTime `spoc:"REG_TIME"`
+		PctUtil    float64
+		Ignored    string `spoc:"-"`
+	}
+	if err := result.Scan(&row); err != nil {
+		t.Fatalf("Scan returned error: %v", err)
+	}
+
+	if row.Name != "CLIENT1" {
+		t.Errorf("Scan stored %q in the embedded NODE_NAME field, expected CLIENT1", row.Name)
+	}
+	if row.Contact.Valid {
+		t.Errorf("Scan stored %+v for a NULL CONTACT, expected an invalid NullString", row.Contact)
+	}
+	if !row.LastAccess.Valid || !row.LastAccess.Time.Equal(time.Date(2026, 10, 14, 9, 12, 1, 0, time.Local)) {
+		t.Errorf("Scan stored %+v for LASTACC_TIME", row.LastAccess)
+	}
+	if row.Registered.Valid {
+		t.Errorf("Scan stored %+v for a NULL REG_TIME, expected an invalid NullTime", row.Registered)
+	}
+	if row.PctUtil != 87.5 {
+		t.Errorf("Scan stored %v for PCT_UTIL, expected 87.5", row.PctUtil)
+	}
+}
+
+func TestCommandResult_ScanErrors(t *testing.T) {
+	result, _ := parseCommandResult([]byte(`{"ITEMS":[{"NODE_NAME":"CLIENT1","NUM_FILES":null,"LOCKED":"MAYBE"}],"RC":0}`))
+
+	var nullInt []struct {
+		NumFiles int `spoc:"NUM_FILES"`
+	}
+	if err := result.Scan(&nullInt); err == nil {
+		t.Error("Scan of NULL into an int without nullzero did not return an error")
+	}
+
+	var badBool []struct{ Locked bool }
+	if err := result.Scan(&badBool); err == nil {
+		t.Error("Scan of MAYBE into a bool did not return an error")
+	}
+
+	var notSlice []string
+	if err := result.Scan(&notSlice); err == nil {
+		t.Error("Scan into a slice of strings did not return an error")
+	}
+
+	var single struct{ NodeName string }
+	empty := &CommandResult{Command: "SELECT * FROM NODES"}
+	if err := empty.Scan(&single); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Scan of no rows into a struct returned %v, expected ErrNotFound", err)
+	}
+	if err := empty.scan(&single, false); err != nil {
+		t.Errorf("scan of no rows for Query returned %v, expected no error", err)
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	expected := time.Date(2026, 10, 14, 9, 12, 1, 0, time.Local)
+
+	for _, value := range []string{
+		"2026-10-14 09:12:01.000000",
+		"2026-10-14 09:12:01",
+		"2026-10-14-09.12.01.000000",
+		"10/14/2026 09:12:01",
+		"10/14/26 09:12:01",
+		" 2026-10-14 09:12:01 ",
+	} {
+		ts, err := parseTimestamp(value)
+		if err != nil {
+			t.Errorf("parseTimestamp(%q) returned error: %v", value, err)
+			continue
+		}
+		if !ts.Equal(expected) {
+			t.Errorf("parseTimestamp(%q) returned %v, expected %v", value, ts, expected)
+		}
+	}
+
+	if ts, err := parseTimestamp("2026-10-14"); err != nil || !ts.Equal(time.Date(2026, 10, 14, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("parseTimestamp of a date returned %v, %v", ts, err)
+	}
+
+	if _, err := parseTimestamp("yesterday"); err == nil {
+		t.Error("parseTimestamp of an unknown format did not return an error")
+	}
+}
+
+func TestIsSelect(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected bool
+	}{
+		{"SELECT * FROM NODES", true},
+		{"  select node_name from nodes;", true},
+		{"SELECT * FROM NODES WHERE CONTACT='a;b'", true},
+		{"SELECT * FROM NODES; DELETE VOLUME V1", false},
+		{"SELECT * FROM NODES WHERE CONTACT='unterminated", false},
+		{"QUERY NODE", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := isSelect(tt.query); got != tt.expected {
+			t.Errorf("isSelect(%q) returned %v, expected %v", tt.query, got, tt.expected)
+		}
+	}
+}
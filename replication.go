@@ -0,0 +1,289 @@
+package gospoc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Replication is an interface for interacting with
+// IBM Spectrum Protect node replication
+type Replication interface {
+	FileSpaces(ctx context.Context, serverName string, clientName string) ([]FileSpaceReplication, *http.Response, error)
+	Nodes(ctx context.Context, serverName string) ([]NodeReplication, *http.Response, error)
+	Replicate(ctx context.Context, serverName string, replicateRequest *ReplicateRequest, opts *ProcessWaitOptions) (*ProcessResult, *http.Response, error)
+	Stale(ctx context.Context, serverName string, maxAge time.Duration) ([]NodeReplication, *http.Response, error)
+	Start(ctx context.Context, serverName string, replicateRequest *ReplicateRequest) (int64, *http.Response, error)
+}
+
+// ReplicationOp handles communication with the node replication related methods of the
+// IBM Spectrum Protect Operations Center REST API
+type ReplicationOp struct {
+	client *Client
+}
+
+// NodeReplication is the replication configuration and state of a backup client
+type NodeReplication struct {
+	Server string `spoc:"-"`
+	Client string `spoc:"NODE_NAME"`
+	Domain string `spoc:"DOMAIN_NAME,nullzero"`
+
+	// State is ENABLED or DISABLED, and Mode is SEND, RECEIVE, SYNCSEND, SYNCRECEIVE or NONE
+	State string `spoc:"REPL_STATE,nullzero"`
+	Mode  string `spoc:"REPL_MODE,nullzero"`
+
+	// Rules are the default replication rules of each type of data
+	BackupRule       string `spoc:"BKREPL_RULE_DEFAULT,nullzero"`
+	ArchiveRule      string `spoc:"ARREPL_RULE_DEFAULT,nullzero"`
+	SpaceManagedRule string `spoc:"SPREPL_RULE_DEFAULT,nullzero"`
+
+	// FileSpaces is the number of file spaces of the client
+	FileSpaces int `spoc:"-"`
+
+	// LastReplication is the oldest last replication of the file spaces of the
+	// client, so every file space replicated since then. It is zero when a file
+	// space never replicated.
+	LastReplication time.Time `spoc:"-"`
+}
+
+// Sends reports whether the client replicates its data to another server
+func (n *NodeReplication) Sends() bool {
+	return strings.EqualFold(n.State, "ENABLED") && strings.HasSuffix(strings.ToUpper(n.Mode), "SEND")
+}
+
+// FileSpaceReplication compares the files of a file space with its replica
+type FileSpaceReplication struct {
+	Server            string
+	Client            string
+	Type              string
+	FileSpace         string
+	FileSpaceID       int
+	ReplicationServer string
+
+	Files        int64
+	ReplicaFiles int64
+	FilesBehind  int64
+
+	// BytesBehind is estimated from the average size of the files of the file space
+	BytesBehind ByteSize
+
+	LastReplicationStart time.Time
+	LastReplication      time.Time
+}
+
+// ReplicateRequest represents a request to replicate clients to their replication server
+type ReplicateRequest struct {
+	// Nodes are the names of clients or client groups
+	Nodes []string
+
+	// DataType is ALL, BACKUP, ARCHIVE, SPACEMANAGED or their ACTIVE variants
+	DataType       string
+	MaxSessions    *int
+	ForceReconcile *bool
+}
+
+type fileSpaceReplRow struct {
+	Client      string    `spoc:"NODE_NAME"`
+	FileSpace   string    `spoc:"FILESPACE_NAME,nullzero"`
+	FileSpaceID int       `spoc:"FILESPACE_ID,nullzero"`
+	LastStart   time.Time `spoc:"LAST_REPL_START,nullzero"`
+	LastEnd     time.Time `spoc:"LAST_REPL_COMP,nullzero"`
+}
+
+type replNodeRow struct {
+	Client            string `spoc:"NODE_NAME"`
+	Type              string `spoc:"TYPE,nullzero"`
+	FileSpace         string `spoc:"FILESPACE_NAME,nullzero"`
+	FileSpaceID       int    `spoc:"FSID,nullzero"`
+	Files             int64  `spoc:"FILES_ON_SERVER,nullzero"`
+	ReplicationServer string `spoc:"REPLICATION_SERVER,nullzero"`
+	ReplicaFiles      int64  `spoc:"FILES_ON_REPLICATION_SERVER,nullzero"`
+}
+
+// Nodes lists the replication configuration and last replication of the clients of a backup server
+func (s *ReplicationOp) Nodes(ctx context.Context, serverName string) ([]NodeReplication, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	var nodes []NodeReplication
+	resp, err := s.client.CLI.Query(ctx, serverName, selectFrom("NODES"), &nodes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var fileSpaces []fileSpaceReplRow
+	resp, err = s.client.CLI.Query(ctx, serverName, selectFrom("FILESPACES"), &fileSpaces)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	// A zero time records a file space that never replicated
+	last := make(map[string]time.Time)
+	count := make(map[string]int)
+	for _, fs := range fileSpaces {
+		count[fs.Client]++
+		if t, ok := last[fs.Client]; !ok || fs.LastEnd.Before(t) {
+			last[fs.Client] = fs.LastEnd
+		}
+	}
+
+	for i := range nodes {
+		nodes[i].Server = serverName
+		nodes[i].FileSpaces = count[nodes[i].Client]
+		nodes[i].LastReplication = last[nodes[i].Client]
+	}
+
+	return nodes, resp, err
+}
+
+// Stale lists the clients that replicate their data but have a file space that did not
+// replicate within maxAge. Clients without file spaces are never stale.
+func (s *ReplicationOp) Stale(ctx context.Context, serverName string, maxAge time.Duration) ([]NodeReplication, *http.Response, error) {
+	if maxAge <= 0 {
+		return nil, nil, NewArgError("maxAge", "must be positive")
+	}
+
+	nodes, resp, err := s.Nodes(ctx, serverName)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+
+	var stale []NodeReplication
+	for _, node := range nodes {
+		if node.Sends() && node.FileSpaces > 0 && node.LastReplication.Before(cutoff) {
+			stale = append(stale, node)
+		}
+	}
+
+	return stale, resp, err
+}
+
+// FileSpaces compares the files of each file space of a client with its replica
+func (s *ReplicationOp) FileSpaces(ctx context.Context, serverName string, clientName string) ([]FileSpaceReplication, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if clientName == "" {
+		return nil, nil, NewArgError("clientName", "cannot be empty")
+	}
+
+	result, resp, err := s.client.runCommand(ctx, serverName, newCommand("QUERY", "REPLNODE").arg(clientName))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var rows []replNodeRow
+	if err := result.Scan(&rows); err != nil {
+		return nil, resp, err
+	}
+
+	var fileSpaces []fileSpaceReplRow
+	resp, err = s.client.CLI.Query(ctx, serverName, selectFrom("FILESPACES", sqlName("NODE_NAME", clientName)), &fileSpaces)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	occupancy, resp, err := s.client.Occupancy.List(ctx, serverName, &OccupancyFilter{Client: clientName})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	times := make(map[int]fileSpaceReplRow)
+	for _, fs := range fileSpaces {
+		times[fs.FileSpaceID] = fs
+	}
+
+	list := make([]FileSpaceReplication, len(rows))
+	for i, row := range rows {
+		behind := row.Files - row.ReplicaFiles
+		if behind < 0 {
+			behind = 0
+		}
+
+		list[i] = FileSpaceReplication{
+			Server:               serverName,
+			Client:               row.Client,
+			Type:                 row.Type,
+			FileSpace:            row.FileSpace,
+			FileSpaceID:          row.FileSpaceID,
+			ReplicationServer:    row.ReplicationServer,
+			Files:                row.Files,
+			ReplicaFiles:         row.ReplicaFiles,
+			FilesBehind:          behind,
+			BytesBehind:          ByteSize(behind) * averageFileSize(occupancy, row.FileSpaceID, row.Type),
+			LastReplicationStart: times[row.FileSpaceID].LastStart,
+			LastReplication:      times[row.FileSpaceID].LastEnd,
+		}
+	}
+
+	return list, resp, err
+}
+
+// averageFileSize returns the average size of the files of a file space. The storage
+// pool holding the most files is used, since copies in other pools hold the same files.
+func averageFileSize(occupancy []OccupancyRecord, fileSpaceID int, dataType string) ByteSize {
+	var best *OccupancyRecord
+	for i := range occupancy {
+		o := &occupancy[i]
+		if o.FileSpaceID != fileSpaceID || !strings.EqualFold(o.Type, dataType) {
+			continue
+		}
+		if best == nil || o.NumFiles > best.NumFiles {
+			best = o
+		}
+	}
+
+	if best == nil || best.NumFiles == 0 {
+		return 0
+	}
+	return best.Logical / ByteSize(best.NumFiles)
+}
+
+// Start replicates clients or client groups in a background process and returns its number
+func (s *ReplicationOp) Start(ctx context.Context, serverName string, replicateRequest *ReplicateRequest) (int64, *http.Response, error) {
+	if replicateRequest == nil {
+		return 0, nil, NewArgError("replicateRequest", "cannot be nil")
+	}
+
+	if serverName == "" {
+		return 0, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if len(replicateRequest.Nodes) == 0 {
+		return 0, nil, NewArgError("replicateRequest.Nodes", "cannot be empty")
+	}
+
+	cmd := newCommand("REPLICATE", "NODE").argList(replicateRequest.Nodes).
+		param("DATATYPE", replicateRequest.DataType).
+		paramInt("MAXSESSIONS", replicateRequest.MaxSessions).
+		paramBool("FORCERECONCILE", replicateRequest.ForceReconcile).
+		param("WAIT", "NO")
+
+	result, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	if err != nil {
+		return 0, resp, err
+	}
+
+	processNumber, ok := startedProcess(result)
+	if !ok {
+		return 0, resp, fmt.Errorf("Unable to replicate %s on server %s: %w", strings.Join(replicateRequest.Nodes, ","), serverName, ErrNoProcess)
+	}
+
+	return processNumber, resp, err
+}
+
+// Replicate replicates clients or client groups, then waits for the replication process to end
+func (s *ReplicationOp) Replicate(ctx context.Context, serverName string, replicateRequest *ReplicateRequest, opts *ProcessWaitOptions) (*ProcessResult, *http.Response, error) {
+	processNumber, resp, err := s.Start(ctx, serverName, replicateRequest)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return s.client.Processes.Wait(ctx, serverName, processNumber, opts)
+}
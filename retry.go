@@ -0,0 +1,257 @@
+package gospoc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryPolicy defines how failed requests to the
+// IBM Spectrum Protect Operations Center are retried
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a request,
+	// including the first one. Values below 2 disable retries.
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first retry. Each following
+	// retry doubles the delay up to MaxBackoff.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the delay between attempts, including delays
+	// requested by the server through the Retry-After header.
+	MaxBackoff time.Duration
+
+	// RetryStatusCodes lists the HTTP status codes that are retried.
+	RetryStatusCodes []int
+
+	// RetryNonIdempotent allows requests with methods other than GET, HEAD
+	// and OPTIONS to be retried. Use WithIdempotent to allow it for a
+	// single request instead.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns the retry policy used by NewClient
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		RetryStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// RetryError reports a request that still failed after being retried
+type RetryError struct {
+	// Number of attempts made
+	Attempts int
+
+	// Error returned by the last attempt
+	Err error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("giving up after %d attempts: %v", e.Attempts, e.Err)
+}
+
+// Unwrap returns the error of the last attempt
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// attemptsKey holds the number of attempts made for a request in the context
+// of the requests sent, which responses refer to through their Request field
+type attemptsKey struct{}
+
+// Attempts returns the number of attempts made for the request that produced
+// resp, or 0 when resp was not returned by Client.Do or DoRequestWithRetry
+func Attempts(resp *http.Response) int {
+	if resp == nil || resp.Request == nil {
+		return 0
+	}
+
+	if attempts, ok := resp.Request.Context().Value(attemptsKey{}).(*int); ok {
+		return *attempts
+	}
+	return 0
+}
+
+type idempotentKey struct{}
+
+// WithIdempotent marks the requests made with the returned context as safe
+// to retry, regardless of their HTTP method
+func WithIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+// DoRequestWithRetry submits an HTTP request using the specified client, retrying it
+// according to policy. A nil policy makes a single attempt.
+func DoRequestWithRetry(
+	ctx context.Context,
+	client *http.Client,
+	req *http.Request,
+	policy *RetryPolicy) (*http.Response, error) {
+	resp, attempts, err := doRequestWithRetry(ctx, client, req, policy)
+	if err != nil && attempts > 1 {
+		err = &RetryError{Attempts: attempts, Err: err}
+	}
+
+	return resp, err
+}
+
+// doRequestWithRetry returns the last response or error along with the number
+// of attempts made. A response with a retryable status code is returned without
+// an error once the attempts are exhausted so that the caller can check it.
+func doRequestWithRetry(
+	ctx context.Context,
+	client *http.Client,
+	req *http.Request,
+	policy *RetryPolicy) (*http.Response, int, error) {
+	attempt := 1
+	ctx = context.WithValue(ctx, attemptsKey{}, &attempt)
+	for {
+		resp, err := DoRequestWithClient(ctx, client, req)
+		if !policy.shouldRetry(ctx, req, resp, err, attempt) {
+			return resp, attempt, err
+		}
+
+		wait := policy.backoff(attempt, resp)
+		if resp != nil {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if req.GetBody != nil {
+			body, berr := req.GetBody()
+			if berr != nil {
+				return nil, attempt, berr
+			}
+			req.Body = body
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, attempt, ctx.Err()
+		case <-timer.C:
+		}
+
+		attempt++
+	}
+}
+
+func (p *RetryPolicy) shouldRetry(ctx context.Context, req *http.Request, resp *http.Response, err error, attempt int) bool {
+	if p == nil || attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if !p.RetryNonIdempotent && !isIdempotent(ctx, req) {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	for _, code := range p.RetryStatusCodes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isIdempotent(ctx context.Context, req *http.Request) bool {
+	if v, ok := ctx.Value(idempotentKey{}).(bool); ok && v {
+		return true
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	return false
+}
+
+// backoff returns the delay before the next attempt. Retry-After takes
+// precedence over the exponential backoff when the server sends it.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+				wait = p.MaxBackoff
+			}
+			return wait
+		}
+	}
+
+	wait := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || wait < p.MaxBackoff); i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	if wait <= 0 {
+		return 0
+	}
+
+	// Equal jitter: keep half of the delay and randomize the other half
+	half := wait / 2
+	return half + jitter(wait-half)
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		wait := time.Until(t)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+var (
+	jitterMu   sync.Mutex
+	jitterRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+func jitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+
+	jitterMu.Lock()
+	defer jitterMu.Unlock()
+	return time.Duration(jitterRand.Int63n(int64(max) + 1))
+}
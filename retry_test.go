@@ -0,0 +1,187 @@
+package gospoc
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fastRetryPolicy retries 503 responses without waiting long between attempts
+func fastRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:      3,
+		MinBackoff:       time.Millisecond,
+		MaxBackoff:       10 * time.Millisecond,
+		RetryStatusCodes: []int{http.StatusServiceUnavailable},
+	}
+}
+
+// retryServer answers 503 to the first failures requests and 200 afterwards,
+// recording the body of every request
+func retryServer(t *testing.T, failures int, retryAfter string) (*Client, *[]string, func()) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+
+	client, err := NewClientWithOptions(&Config{Username: "admin", Password: "secret"},
+		WithBaseURL(server.URL), WithRetryPolicy(fastRetryPolicy()))
+	if err != nil {
+		server.Close()
+		t.Fatalf("NewClientWithOptions returned error: %v", err)
+	}
+
+	return client, &bodies, server.Close
+}
+
+func TestRetry_Succeeds(t *testing.T) {
+	client, bodies, teardown := retryServer(t, 1, "")
+	defer teardown()
+
+	req, err := client.NewRequest(context.Background(), http.MethodGet, "/oc/api/servers", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+
+	resp, err := client.Do(context.Background(), req, nil)
+	if err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	if len(*bodies) != 2 {
+		t.Errorf("Do sent %d requests, expected 2", len(*bodies))
+	}
+
+	if attempts := Attempts(resp); attempts != 2 {
+		t.Errorf("Attempts returned %d, expected 2", attempts)
+	}
+
+	if resp.Header.Get("X-Gospoc-Attempts") != "" {
+		t.Errorf("Do added headers to the response: %v", resp.Header)
+	}
+}
+
+func TestRetry_GivesUp(t *testing.T) {
+	client, bodies, teardown := retryServer(t, 5, "")
+	defer teardown()
+
+	req, _ := client.NewRequest(context.Background(), http.MethodGet, "/oc/api/servers", nil)
+	_, err := client.Do(context.Background(), req, nil)
+
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) || retryErr.Attempts != 3 {
+		t.Errorf("Do returned %v, expected a RetryError after 3 attempts", err)
+	}
+
+	if !errors.Is(err, ErrServerUnavailable) {
+		t.Errorf("Do returned %v, expected ErrServerUnavailable", err)
+	}
+
+	if len(*bodies) != 3 {
+		t.Errorf("Do sent %d requests, expected 3", len(*bodies))
+	}
+}
+
+func TestRetry_NonIdempotent(t *testing.T) {
+	client, bodies, teardown := retryServer(t, 1, "")
+	defer teardown()
+
+	body := map[string]string{"name": "NODE1"}
+	req, _ := client.NewRequest(context.Background(), http.MethodPost, "/oc/api/servers", body)
+	resp, err := client.Do(context.Background(), req, nil)
+	if !errors.Is(err, ErrServerUnavailable) {
+		t.Errorf("Do of a POST returned %v, expected ErrServerUnavailable", err)
+	}
+
+	var retryErr *RetryError
+	if errors.As(err, &retryErr) {
+		t.Errorf("Do of a POST returned %v, expected a single attempt", err)
+	}
+
+	if len(*bodies) != 1 || Attempts(resp) != 1 {
+		t.Errorf("Do of a POST sent %d requests, expected 1", len(*bodies))
+	}
+}
+
+func TestRetry_WithIdempotent(t *testing.T) {
+	client, bodies, teardown := retryServer(t, 1, "")
+	defer teardown()
+
+	ctx := WithIdempotent(context.Background())
+	body := map[string]string{"name": "NODE1"}
+	req, _ := client.NewRequest(ctx, http.MethodPost, "/oc/api/servers", body)
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do of an idempotent POST returned error: %v", err)
+	}
+
+	// The body is replayed through GetBody on the second attempt
+	expected := "{\"name\":\"NODE1\"}\n"
+	if len(*bodies) != 2 || (*bodies)[0] != expected || (*bodies)[1] != expected {
+		t.Errorf("Do of an idempotent POST sent %q, expected %q twice", *bodies, expected)
+	}
+}
+
+func TestRetry_RetryAfterCapped(t *testing.T) {
+	client, bodies, teardown := retryServer(t, 1, "3600")
+	defer teardown()
+
+	req, _ := client.NewRequest(context.Background(), http.MethodGet, "/oc/api/servers", nil)
+
+	start := time.Now()
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Do waited %v, expected Retry-After to be capped by MaxBackoff", elapsed)
+	}
+
+	if len(*bodies) != 2 {
+		t.Errorf("Do sent %d requests, expected 2", len(*bodies))
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			if wait := policy.backoff(tt.attempt, nil); wait < tt.min || wait > tt.max {
+				t.Errorf("backoff(%d) returned %v, expected between %v and %v", tt.attempt, wait, tt.min, tt.max)
+			}
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+	if wait := policy.backoff(1, resp); wait != time.Second {
+		t.Errorf("backoff with Retry-After: 2 returned %v, expected the MaxBackoff of 1s", wait)
+	}
+
+	resp.Header.Set("Retry-After", "-1")
+	if wait := policy.backoff(1, resp); wait > 100*time.Millisecond {
+		t.Errorf("backoff with an invalid Retry-After returned %v, expected the exponential backoff", wait)
+	}
+}
@@ -0,0 +1,244 @@
+package gospoc
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Schedules is an interface for interacting with
+// IBM Spectrum Protect client schedules
+type Schedules interface {
+	Associate(ctx context.Context, serverName string, domainName string, scheduleName string, clientNames ...string) (*http.Response, error)
+	Copy(ctx context.Context, serverName string, domainName string, scheduleName string, newDomainName string, newScheduleName string) (*http.Response, error)
+	Create(ctx context.Context, serverName string, createRequest *ClientScheduleRequest) (*http.Response, error)
+	Delete(ctx context.Context, serverName string, domainName string, scheduleName string) (*http.Response, error)
+	Disassociate(ctx context.Context, serverName string, domainName string, scheduleName string, clientNames ...string) (*http.Response, error)
+	Get(ctx context.Context, serverName string, domainName string, scheduleName string) (*ClientSchedule, *http.Response, error)
+	List(ctx context.Context, serverName string, domainName string) ([]ClientSchedule, *http.Response, error)
+	Update(ctx context.Context, serverName string, update *ClientScheduleRequest) (*http.Response, error)
+}
+
+// SchedulesOp handles communication with the client schedule related methods of the
+// IBM Spectrum Protect Operations Center REST API
+type SchedulesOp struct {
+	client *Client
+}
+
+// ClientSchedule contains the elements that make up a client schedule
+type ClientSchedule struct {
+	Domain        string    `spoc:"DOMAIN_NAME"`
+	Name          string    `spoc:"SCHEDULE_NAME"`
+	Description   string    `spoc:"DESCRIPTION,nullzero"`
+	Action        string    `spoc:"ACTION,nullzero"`
+	SubAction     string    `spoc:"SUBACTION,nullzero"`
+	Options       string    `spoc:"OPTIONS,nullzero"`
+	Objects       string    `spoc:"OBJECTS,nullzero"`
+	Priority      int       `spoc:"PRIORITY,nullzero"`
+	StartDate     string    `spoc:"STARTDATE,nullzero"`
+	StartTime     string    `spoc:"STARTTIME,nullzero"`
+	Duration      int       `spoc:"DURATION,nullzero"`
+	DurationUnits string    `spoc:"DURUNITS,nullzero"`
+	Period        int       `spoc:"PERIOD,nullzero"`
+	PeriodUnits   string    `spoc:"PERUNITS,nullzero"`
+	DayOfWeek     string    `spoc:"DAYOFWEEK,nullzero"`
+	Expiration    string    `spoc:"EXPIRATION,nullzero"`
+	Style         string    `spoc:"SCHED_STYLE,nullzero"`
+	ChangedBy     string    `spoc:"CHG_ADMIN,nullzero"`
+	ChangedAt     time.Time `spoc:"CHG_TIME,nullzero"`
+	Profile       string    `spoc:"PROFILE,nullzero"`
+}
+
+// ClientScheduleRequest represents a request to define or update a client schedule.
+// Empty fields are left at their defaults on create and unchanged on update.
+type ClientScheduleRequest struct {
+	Domain      string
+	Name        string
+	Description string
+
+	// Action is INCREMENTAL, SELECTIVE, ARCHIVE, RESTORE, COMMAND and so on
+	Action  string
+	Options string
+	Objects string
+
+	Priority *int
+
+	// StartDate and StartTime use the server formats, such as TODAY and 21:00
+	StartDate     string
+	StartTime     string
+	Duration      *int
+	DurationUnits string
+	Period        *int
+	PeriodUnits   string
+
+	// DayOfWeek is ANY, WEEKDAY, WEEKEND or a list of days such as MONDAY,FRIDAY
+	DayOfWeek  string
+	Expiration string
+}
+
+// List the client schedules of a domain, or of every domain when domainName is empty
+func (s *SchedulesOp) List(ctx context.Context, serverName string, domainName string) ([]ClientSchedule, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	var schedules []ClientSchedule
+	query := selectFrom("CLIENT_SCHEDULES", sqlName("DOMAIN_NAME", domainName))
+	resp, err := s.client.CLI.Query(ctx, serverName, query, &schedules)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return schedules, resp, err
+}
+
+// Get the details of a specific client schedule
+func (s *SchedulesOp) Get(ctx context.Context, serverName string, domainName string, scheduleName string) (*ClientSchedule, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if domainName == "" {
+		return nil, nil, NewArgError("domainName", "cannot be empty")
+	}
+
+	if scheduleName == "" {
+		return nil, nil, NewArgError("scheduleName", "cannot be empty")
+	}
+
+	var schedules []ClientSchedule
+	query := selectFrom("CLIENT_SCHEDULES", sqlName("DOMAIN_NAME", domainName), sqlName("SCHEDULE_NAME", scheduleName))
+	resp, err := s.client.CLI.Query(ctx, serverName, query, &schedules)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(schedules) == 0 {
+		return nil, resp, notFound("schedule "+domainName+"/"+scheduleName, serverName)
+	}
+
+	return &schedules[0], resp, err
+}
+
+// Create defines a new client schedule
+func (s *SchedulesOp) Create(ctx context.Context, serverName string, createRequest *ClientScheduleRequest) (*http.Response, error) {
+	return s.scheduleCommand(ctx, "DEFINE", serverName, createRequest, "createRequest")
+}
+
+// Update the settings of a client schedule
+func (s *SchedulesOp) Update(ctx context.Context, serverName string, update *ClientScheduleRequest) (*http.Response, error) {
+	return s.scheduleCommand(ctx, "UPDATE", serverName, update, "update")
+}
+
+func (s *SchedulesOp) scheduleCommand(ctx context.Context, verb string, serverName string, r *ClientScheduleRequest, argName string) (*http.Response, error) {
+	if r == nil {
+		return nil, NewArgError(argName, "cannot be nil")
+	}
+
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if r.Domain == "" || r.Name == "" {
+		return nil, NewArgError(argName, "must name the domain and schedule")
+	}
+
+	cmd := newCommand(verb, "SCHEDULE").arg(r.Domain).arg(r.Name).
+		param("DESCRIPTION", r.Description).
+		param("ACTION", r.Action).
+		param("OPTIONS", r.Options).
+		param("OBJECTS", r.Objects).
+		paramInt("PRIORITY", r.Priority).
+		param("STARTDATE", r.StartDate).
+		param("STARTTIME", r.StartTime).
+		paramInt("DURATION", r.Duration).
+		param("DURUNITS", r.DurationUnits).
+		paramInt("PERIOD", r.Period).
+		param("PERUNITS", r.PeriodUnits).
+		paramList("DAYOFWEEK", splitList(r.DayOfWeek)).
+		param("EXPIRATION", r.Expiration)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// Copy a client schedule to a new schedule, possibly in another domain
+func (s *SchedulesOp) Copy(ctx context.Context, serverName string, domainName string, scheduleName string, newDomainName string, newScheduleName string) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if domainName == "" {
+		return nil, NewArgError("domainName", "cannot be empty")
+	}
+
+	if scheduleName == "" {
+		return nil, NewArgError("scheduleName", "cannot be empty")
+	}
+
+	if newDomainName == "" {
+		return nil, NewArgError("newDomainName", "cannot be empty")
+	}
+
+	if newScheduleName == "" {
+		newScheduleName = scheduleName
+	}
+
+	cmd := newCommand("COPY", "SCHEDULE").arg(domainName).arg(scheduleName).arg(newDomainName).arg(newScheduleName)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// Delete a client schedule along with its associations
+func (s *SchedulesOp) Delete(ctx context.Context, serverName string, domainName string, scheduleName string) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if domainName == "" {
+		return nil, NewArgError("domainName", "cannot be empty")
+	}
+
+	if scheduleName == "" {
+		return nil, NewArgError("scheduleName", "cannot be empty")
+	}
+
+	cmd := newCommand("DELETE", "SCHEDULE").arg(domainName).arg(scheduleName)
+
+	_, resp, err := s.client.runConfirmedCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// Associate backup clients with a client schedule
+func (s *SchedulesOp) Associate(ctx context.Context, serverName string, domainName string, scheduleName string, clientNames ...string) (*http.Response, error) {
+	return s.associationCommand(ctx, "DEFINE", serverName, domainName, scheduleName, clientNames)
+}
+
+// Disassociate removes the association between backup clients and a client schedule
+func (s *SchedulesOp) Disassociate(ctx context.Context, serverName string, domainName string, scheduleName string, clientNames ...string) (*http.Response, error) {
+	return s.associationCommand(ctx, "DELETE", serverName, domainName, scheduleName, clientNames)
+}
+
+func (s *SchedulesOp) associationCommand(ctx context.Context, verb string, serverName string, domainName string, scheduleName string, clientNames []string) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if domainName == "" {
+		return nil, NewArgError("domainName", "cannot be empty")
+	}
+
+	if scheduleName == "" {
+		return nil, NewArgError("scheduleName", "cannot be empty")
+	}
+
+	if len(clientNames) == 0 {
+		return nil, NewArgError("clientNames", "cannot be empty")
+	}
+
+	cmd := newCommand(verb, "ASSOCIATION").arg(domainName).arg(scheduleName).argList(clientNames)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
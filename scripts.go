@@ -0,0 +1,275 @@
+package gospoc
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Scripts is an interface for interacting with
+// IBM Spectrum Protect server scripts
+type Scripts interface {
+	Create(ctx context.Context, serverName string, createRequest *ScriptRequest) (*http.Response, error)
+	Delete(ctx context.Context, serverName string, scriptName string) (*http.Response, error)
+	DeleteLine(ctx context.Context, serverName string, scriptName string, line int) (*http.Response, error)
+	Get(ctx context.Context, serverName string, scriptName string) (*Script, *http.Response, error)
+	List(ctx context.Context, serverName string) ([]Script, *http.Response, error)
+	Run(ctx context.Context, serverName string, scriptName string, params ...string) (*CommandResult, *http.Response, error)
+	SetLine(ctx context.Context, serverName string, scriptName string, line int, command string) (*http.Response, error)
+	UpdateDescription(ctx context.Context, serverName string, scriptName string, description string) (*http.Response, error)
+}
+
+// ScriptsOp handles communication with the server script related methods of the
+// IBM Spectrum Protect Operations Center REST API
+type ScriptsOp struct {
+	client *Client
+}
+
+// Script contains the elements that make up a server script
+type Script struct {
+	Name        string
+	Description string
+	Profile     string
+	Lines       []ScriptLine
+}
+
+// Commands returns the commands of the script in line order
+func (s *Script) Commands() []string {
+	commands := make([]string, len(s.Lines))
+	for i, line := range s.Lines {
+		commands[i] = line.Command
+	}
+	return commands
+}
+
+// ScriptLine is a line of a server script
+type ScriptLine struct {
+	Number    int       `spoc:"LINE"`
+	Command   string    `spoc:"COMMAND,nullzero"`
+	ChangedBy string    `spoc:"LAST_UPDATE_BY,nullzero"`
+	ChangedAt time.Time `spoc:"LAST_UPDATE,nullzero"`
+}
+
+// ScriptRequest represents a request to define a server script
+type ScriptRequest struct {
+	Name        string
+	Description string
+
+	// Commands become the lines of the script, numbered by five
+	Commands []string
+}
+
+const scriptLineIncrement = 5
+
+type scriptNameRow struct {
+	Name        string `spoc:"NAME"`
+	Description string `spoc:"DESCRIPTION,nullzero"`
+	Profile     string `spoc:"MANAGING_PROFILE,nullzero"`
+}
+
+type scriptLineRow struct {
+	Name string `spoc:"NAME"`
+	ScriptLine
+}
+
+// List all server scripts of a backup server along with their lines
+func (s *ScriptsOp) List(ctx context.Context, serverName string) ([]Script, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	return s.list(ctx, serverName, "")
+}
+
+// Get a specific server script along with its lines
+func (s *ScriptsOp) Get(ctx context.Context, serverName string, scriptName string) (*Script, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if scriptName == "" {
+		return nil, nil, NewArgError("scriptName", "cannot be empty")
+	}
+
+	scripts, resp, err := s.list(ctx, serverName, scriptName)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(scripts) == 0 {
+		return nil, resp, notFound("script "+scriptName, serverName)
+	}
+
+	return &scripts[0], resp, err
+}
+
+func (s *ScriptsOp) list(ctx context.Context, serverName string, scriptName string) ([]Script, *http.Response, error) {
+	var names []scriptNameRow
+	resp, err := s.client.CLI.Query(ctx, serverName, selectFrom("SCRIPT_NAMES", sqlName("NAME", scriptName)), &names)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var lines []scriptLineRow
+	resp, err = s.client.CLI.Query(ctx, serverName, selectFrom("SCRIPTS", sqlName("NAME", scriptName)), &lines)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	scripts := make([]Script, len(names))
+	index := make(map[string]int)
+	for i, name := range names {
+		scripts[i] = Script{Name: name.Name, Description: name.Description, Profile: name.Profile}
+		index[name.Name] = i
+	}
+
+	for _, line := range lines {
+		if i, ok := index[line.Name]; ok {
+			scripts[i].Lines = append(scripts[i].Lines, line.ScriptLine)
+		}
+	}
+
+	for i := range scripts {
+		lines := scripts[i].Lines
+		sort.Slice(lines, func(a, b int) bool { return lines[a].Number < lines[b].Number })
+	}
+
+	return scripts, resp, err
+}
+
+// Create defines a new server script from a list of commands
+func (s *ScriptsOp) Create(ctx context.Context, serverName string, createRequest *ScriptRequest) (*http.Response, error) {
+	if createRequest == nil {
+		return nil, NewArgError("createRequest", "cannot be nil")
+	}
+
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if createRequest.Name == "" {
+		return nil, NewArgError("createRequest.Name", "cannot be empty")
+	}
+
+	if len(createRequest.Commands) == 0 {
+		return nil, NewArgError("createRequest.Commands", "cannot be empty")
+	}
+
+	line := scriptLineIncrement
+	cmd := newCommand("DEFINE", "SCRIPT").arg(createRequest.Name).arg(createRequest.Commands[0]).
+		paramInt("LINE", &line).
+		param("DESCRIPTION", createRequest.Description)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	if err != nil {
+		return resp, err
+	}
+
+	for i, command := range createRequest.Commands[1:] {
+		if resp, err = s.SetLine(ctx, serverName, createRequest.Name, (i+2)*scriptLineIncrement, command); err != nil {
+			return resp, err
+		}
+	}
+
+	return resp, err
+}
+
+// SetLine replaces a line of a server script, or inserts it when the line does not exist
+func (s *ScriptsOp) SetLine(ctx context.Context, serverName string, scriptName string, line int, command string) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if scriptName == "" {
+		return nil, NewArgError("scriptName", "cannot be empty")
+	}
+
+	if line < 1 {
+		return nil, NewArgError("line", "must be positive")
+	}
+
+	if strings.TrimSpace(command) == "" {
+		return nil, NewArgError("command", "cannot be empty")
+	}
+
+	cmd := newCommand("UPDATE", "SCRIPT").arg(scriptName).arg(command).paramInt("LINE", &line)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// DeleteLine removes a line of a server script
+func (s *ScriptsOp) DeleteLine(ctx context.Context, serverName string, scriptName string, line int) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if scriptName == "" {
+		return nil, NewArgError("scriptName", "cannot be empty")
+	}
+
+	if line < 1 {
+		return nil, NewArgError("line", "must be positive")
+	}
+
+	cmd := newCommand("DELETE", "SCRIPT").arg(scriptName).paramInt("LINE", &line)
+
+	_, resp, err := s.client.runConfirmedCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// UpdateDescription changes the description of a server script
+func (s *ScriptsOp) UpdateDescription(ctx context.Context, serverName string, scriptName string, description string) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if scriptName == "" {
+		return nil, NewArgError("scriptName", "cannot be empty")
+	}
+
+	if description == "" {
+		return nil, NewArgError("description", "cannot be empty")
+	}
+
+	cmd := newCommand("UPDATE", "SCRIPT").arg(scriptName).param("DESCRIPTION", description)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// Delete a server script
+func (s *ScriptsOp) Delete(ctx context.Context, serverName string, scriptName string) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if scriptName == "" {
+		return nil, NewArgError("scriptName", "cannot be empty")
+	}
+
+	cmd := newCommand("DELETE", "SCRIPT").arg(scriptName)
+
+	_, resp, err := s.client.runConfirmedCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// Run a server script, substituting params for its $1, $2... variables
+func (s *ScriptsOp) Run(ctx context.Context, serverName string, scriptName string, params ...string) (*CommandResult, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if scriptName == "" {
+		return nil, nil, NewArgError("scriptName", "cannot be empty")
+	}
+
+	cmd := newCommand("RUN").arg(scriptName)
+	for _, p := range params {
+		cmd.arg(p)
+	}
+
+	return s.client.runConfirmedCommand(ctx, serverName, cmd)
+}
@@ -0,0 +1,123 @@
+package gospoc
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Session types accepted by Sessions.Disable and Sessions.Enable
+const (
+	SessionsAll    = "ALL"
+	SessionsClient = "CLIENT"
+	SessionsAdmin  = "ADMIN"
+	SessionsServer = "SERVER"
+)
+
+// Sessions is an interface for interacting with
+// IBM Spectrum Protect sessions
+type Sessions interface {
+	Cancel(ctx context.Context, serverName string, sessionID int64) (*http.Response, error)
+	CancelAll(ctx context.Context, serverName string) (*http.Response, error)
+	Disable(ctx context.Context, serverName string, sessionType string) (*http.Response, error)
+	Enable(ctx context.Context, serverName string, sessionType string) (*http.Response, error)
+	List(ctx context.Context, serverName string) ([]Session, *http.Response, error)
+}
+
+// SessionsOp handles communication with the session related methods of the
+// IBM Spectrum Protect Operations Center REST API
+type SessionsOp struct {
+	client *Client
+}
+
+// Session contains the elements that make up an active session
+type Session struct {
+	ID             int64     `spoc:"SESSION_ID"`
+	StartTime      time.Time `spoc:"START_TIME,nullzero"`
+	CommMethod     string    `spoc:"COMMMETHOD,nullzero"`
+	State          string    `spoc:"STATE,nullzero"`
+	WaitSeconds    int64     `spoc:"WAIT_SECONDS,nullzero"`
+	BytesSent      ByteSize  `spoc:"BYTES_SENT,nullzero"`
+	BytesReceived  ByteSize  `spoc:"BYTES_RECEIVED,nullzero"`
+	Type           string    `spoc:"SESSION_TYPE,nullzero"`
+	Platform       string    `spoc:"CLIENT_PLATFORM,nullzero"`
+	Client         string    `spoc:"CLIENT_NAME,nullzero"`
+	Owner          string    `spoc:"OWNER_NAME,nullzero"`
+	MountPointWait int64     `spoc:"MOUNT_POINT_WAIT,nullzero"`
+	LastVerb       string    `spoc:"LAST_VERB,nullzero"`
+	VerbState      string    `spoc:"VERB_STATE,nullzero"`
+}
+
+// Wait returns how long the session has been waiting
+func (s *Session) Wait() time.Duration {
+	return time.Duration(s.WaitSeconds) * time.Second
+}
+
+// List the active sessions of a backup server
+func (s *SessionsOp) List(ctx context.Context, serverName string) ([]Session, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	var sessions []Session
+	resp, err := s.client.CLI.Query(ctx, serverName, selectFrom("SESSIONS"), &sessions)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sessions, resp, err
+}
+
+// Cancel an active session
+func (s *SessionsOp) Cancel(ctx context.Context, serverName string, sessionID int64) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if sessionID <= 0 {
+		return nil, NewArgError("sessionID", "must be positive")
+	}
+
+	cmd := newCommand("CANCEL", "SESSION", strconv.FormatInt(sessionID, 10))
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}
+
+// CancelAll cancels every active client session
+func (s *SessionsOp) CancelAll(ctx context.Context, serverName string) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	_, resp, err := s.client.runConfirmedCommand(ctx, serverName, newCommand("CANCEL", "SESSION", "ALL"))
+	return resp, err
+}
+
+// Disable prevents new sessions of sessionType from starting. Active sessions are not cancelled.
+func (s *SessionsOp) Disable(ctx context.Context, serverName string, sessionType string) (*http.Response, error) {
+	return s.sessionsCommand(ctx, "DISABLE", serverName, sessionType)
+}
+
+// Enable allows new sessions of sessionType to start
+func (s *SessionsOp) Enable(ctx context.Context, serverName string, sessionType string) (*http.Response, error) {
+	return s.sessionsCommand(ctx, "ENABLE", serverName, sessionType)
+}
+
+func (s *SessionsOp) sessionsCommand(ctx context.Context, verb string, serverName string, sessionType string) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	switch sessionType {
+	case "":
+		sessionType = SessionsClient
+	case SessionsAll, SessionsClient, SessionsAdmin, SessionsServer:
+	default:
+		return nil, NewArgError("sessionType", "must be ALL, CLIENT, ADMIN or SERVER")
+	}
+
+	_, resp, err := s.client.runCommand(ctx, serverName, newCommand(verb, "SESSIONS", sessionType))
+	return resp, err
+}
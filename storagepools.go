@@ -0,0 +1,179 @@
+package gospoc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// StoragePoolType describes how a storage pool stores data
+type StoragePoolType string
+
+// Storage pool types
+const (
+	StoragePoolRandom             StoragePoolType = "random"
+	StoragePoolSequential         StoragePoolType = "sequential"
+	StoragePoolDirectoryContainer StoragePoolType = "directory-container"
+	StoragePoolCloudContainer     StoragePoolType = "cloud-container"
+)
+
+// StoragePools is an interface for interacting with
+// IBM Spectrum Protect storage pools
+type StoragePools interface {
+	Get(ctx context.Context, serverName string, poolName string) (*StoragePool, *http.Response, error)
+	List(ctx context.Context, serverName string) ([]StoragePool, *http.Response, error)
+}
+
+// StoragePoolsOp handles communication with the storage pool related methods of the
+// IBM Spectrum Protect Operations Center REST API
+type StoragePoolsOp struct {
+	client *Client
+}
+
+// StoragePool contains the elements that make up a storage pool
+type StoragePool struct {
+	Server      string
+	Name        string
+	Type        StoragePoolType
+	PoolType    string
+	DeviceClass string
+	Description string
+	Access      string
+
+	Capacity    ByteSize
+	Used        ByteSize
+	PctUtilized float64
+	PctMigrate  float64
+
+	// Migration thresholds, in percent
+	HighMigration int
+	LowMigration  int
+
+	// NextPool is the pool data migrates to, and NextPoolChain every pool
+	// following it down the hierarchy
+	NextPool      string
+	NextPoolChain []string
+
+	Deduplicate      bool
+	SpaceSaved       ByteSize
+	DedupSpaceSaved  ByteSize
+	CompressionSaved ByteSize
+}
+
+type storagePoolRow struct {
+	Name              string  `spoc:"STGPOOL_NAME"`
+	PoolType          string  `spoc:"POOLTYPE,nullzero"`
+	DeviceClass       string  `spoc:"DEVCLASS,nullzero"`
+	StgType           string  `spoc:"STG_TYPE,nullzero"`
+	Description       string  `spoc:"DESCRIPTION,nullzero"`
+	Access            string  `spoc:"ACCESS,nullzero"`
+	EstCapacityMB     float64 `spoc:"EST_CAPACITY_MB,nullzero"`
+	PctUtilized       float64 `spoc:"PCT_UTILIZED,nullzero"`
+	PctMigrate        float64 `spoc:"PCT_MIGR,nullzero"`
+	HighMigration     int     `spoc:"HIGHMIG,nullzero"`
+	LowMigration      int     `spoc:"LOWMIG,nullzero"`
+	NextPool          string  `spoc:"NEXTSTGPOOL,nullzero"`
+	Deduplicate       string  `spoc:"DEDUPLICATE,nullzero"`
+	SpaceSavedMB      float64 `spoc:"SPACE_SAVED_MB,nullzero"`
+	DedupSpaceSavedMB float64 `spoc:"DEDUP_SPACE_SAVED_MB,nullzero"`
+	CompSpaceSavedMB  float64 `spoc:"COMP_SPACE_SAVED_MB,nullzero"`
+}
+
+// List all storage pools of a backup server
+func (s *StoragePoolsOp) List(ctx context.Context, serverName string) ([]StoragePool, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	var rows []storagePoolRow
+	resp, err := s.client.CLI.Query(ctx, serverName, selectFrom("STGPOOLS"), &rows)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	next := make(map[string]string)
+	for _, row := range rows {
+		next[row.Name] = row.NextPool
+	}
+
+	pools := make([]StoragePool, len(rows))
+	for i, row := range rows {
+		pools[i] = row.storagePool(serverName, next)
+	}
+
+	return pools, resp, err
+}
+
+// Get the details of a specific storage pool
+func (s *StoragePoolsOp) Get(ctx context.Context, serverName string, poolName string) (*StoragePool, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if poolName == "" {
+		return nil, nil, NewArgError("poolName", "cannot be empty")
+	}
+
+	// All pools are listed to follow the chain of next pools
+	pools, resp, err := s.List(ctx, serverName)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	for i := range pools {
+		if strings.EqualFold(pools[i].Name, poolName) {
+			return &pools[i], resp, err
+		}
+	}
+
+	return nil, resp, notFound("storage pool "+poolName, serverName)
+}
+
+func (r *storagePoolRow) storagePool(serverName string, next map[string]string) StoragePool {
+	capacity := byteSizeFromMB(r.EstCapacityMB)
+
+	pool := StoragePool{
+		Server:           serverName,
+		Name:             r.Name,
+		Type:             r.poolType(),
+		PoolType:         r.PoolType,
+		DeviceClass:      r.DeviceClass,
+		Description:      r.Description,
+		Access:           r.Access,
+		Capacity:         capacity,
+		Used:             ByteSize(float64(capacity) * r.PctUtilized / 100),
+		PctUtilized:      r.PctUtilized,
+		PctMigrate:       r.PctMigrate,
+		HighMigration:    r.HighMigration,
+		LowMigration:     r.LowMigration,
+		NextPool:         r.NextPool,
+		Deduplicate:      strings.EqualFold(r.Deduplicate, "YES"),
+		SpaceSaved:       byteSizeFromMB(r.SpaceSavedMB),
+		DedupSpaceSaved:  byteSizeFromMB(r.DedupSpaceSavedMB),
+		CompressionSaved: byteSizeFromMB(r.CompSpaceSavedMB),
+	}
+
+	// Follow the next pools, stopping at loops
+	seen := map[string]bool{r.Name: true}
+	for name := r.NextPool; name != "" && !seen[name]; name = next[name] {
+		seen[name] = true
+		pool.NextPoolChain = append(pool.NextPoolChain, name)
+	}
+
+	return pool
+}
+
+func (r *storagePoolRow) poolType() StoragePoolType {
+	switch strings.ToUpper(r.StgType) {
+	case "DIRECTORY":
+		return StoragePoolDirectoryContainer
+	case "CLOUD":
+		return StoragePoolCloudContainer
+	}
+
+	// Random access pools use the predefined DISK device class
+	if strings.EqualFold(r.DeviceClass, "DISK") {
+		return StoragePoolRandom
+	}
+	return StoragePoolSequential
+}
@@ -0,0 +1,115 @@
+package gospoc
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// newTransport returns an HTTP transport that owns its TLS configuration,
+// leaving http.DefaultTransport untouched
+func newTransport(config *Config) (*http.Transport, error) {
+	tlsConfig, err := config.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
+
+// tlsConfig builds the TLS configuration described by the Config
+func (c *Config) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: c.MinTLSVersion,
+		ServerName: c.TLSServerName,
+	}
+
+	if c.CACertFile != "" || len(c.CACertPEM) > 0 {
+		pool := x509.NewCertPool()
+
+		if c.CACertFile != "" {
+			pem, err := ioutil.ReadFile(c.CACertFile)
+			if err != nil {
+				return nil, err
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, NewArgError("CACertFile", "contains no PEM encoded certificates")
+			}
+		}
+
+		if len(c.CACertPEM) > 0 && !pool.AppendCertsFromPEM(c.CACertPEM) {
+			return nil, NewArgError("CACertPEM", "contains no PEM encoded certificates")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.ClientCertFile != "" || c.ClientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.ClientCertFile, c.ClientKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = append(tlsConfig.Certificates, cert)
+	}
+
+	if len(c.ClientCertPEM) > 0 || len(c.ClientKeyPEM) > 0 {
+		cert, err := tls.X509KeyPair(c.ClientCertPEM, c.ClientKeyPEM)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = append(tlsConfig.Certificates, cert)
+	}
+
+	if len(c.PinnedCertSHA256) > 0 {
+		pins := make(map[string]bool)
+		for _, pin := range c.PinnedCertSHA256 {
+			fingerprint, err := normalizeFingerprint(pin)
+			if err != nil {
+				return nil, err
+			}
+			pins[fingerprint] = true
+		}
+
+		// The pinned fingerprints are the trust anchor, which allows
+		// connecting to Operations Center hosts with self-signed certificates
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return errors.New("server presented no certificate")
+			}
+
+			sum := sha256.Sum256(rawCerts[0])
+			if !pins[hex.EncodeToString(sum[:])] {
+				return fmt.Errorf("server certificate fingerprint %x is not pinned", sum)
+			}
+
+			return nil
+		}
+	} else if !c.SSLVerify {
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	return tlsConfig, nil
+}
+
+// normalizeFingerprint accepts hex SHA-256 fingerprints with or without
+// colon separators, in either case
+func normalizeFingerprint(pin string) (string, error) {
+	fingerprint := strings.ToLower(strings.Replace(pin, ":", "", -1))
+
+	b, err := hex.DecodeString(fingerprint)
+	if err != nil || len(b) != sha256.Size {
+		return "", NewArgError("PinnedCertSHA256", fmt.Sprintf("%q is not a hex encoded SHA-256 fingerprint", pin))
+	}
+
+	return fingerprint, nil
+}
@@ -0,0 +1,170 @@
+package gospoc
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Volume access modes
+const (
+	VolumeReadWrite   = "READWRITE"
+	VolumeReadOnly    = "READONLY"
+	VolumeUnavailable = "UNAVAILABLE"
+	VolumeDestroyed   = "DESTROYED"
+	VolumeOffsite     = "OFFSITE"
+)
+
+// Volumes is an interface for interacting with
+// IBM Spectrum Protect storage pool volumes
+type Volumes interface {
+	Get(ctx context.Context, serverName string, volumeName string) (*Volume, *http.Response, error)
+	List(ctx context.Context, serverName string, filter *VolumeFilter) ([]Volume, *http.Response, error)
+	UpdateAccess(ctx context.Context, serverName string, volumeName string, access string) (*http.Response, error)
+}
+
+// VolumesOp handles communication with the volume related methods of the
+// IBM Spectrum Protect Operations Center REST API
+type VolumesOp struct {
+	client *Client
+}
+
+// Volume contains the elements that make up a storage pool volume
+type Volume struct {
+	Name        string
+	StoragePool string
+	DeviceClass string
+	Capacity    ByteSize
+	PctUtilized float64
+	PctReclaim  float64
+
+	// Status is FILLING, FULL, EMPTY or PENDING for sequential volumes, and ONLINE or
+	// OFFLINE for random access volumes
+	Status string
+
+	// Access is one of the volume access modes, such as VolumeReadOnly
+	Access string
+
+	Scratch      bool
+	ErrorState   bool
+	TimesMounted int
+	Location     string
+	LastWrite    time.Time
+	LastRead     time.Time
+}
+
+// VolumeFilter selects volumes. Empty fields match every volume.
+type VolumeFilter struct {
+	StoragePool string
+	DeviceClass string
+	Status      string
+	Access      string
+}
+
+type volumeRow struct {
+	Name          string    `spoc:"VOLUME_NAME"`
+	StoragePool   string    `spoc:"STGPOOL_NAME,nullzero"`
+	DeviceClass   string    `spoc:"DEVCLASS_NAME,nullzero"`
+	EstCapacityMB float64   `spoc:"EST_CAPACITY_MB,nullzero"`
+	PctUtilized   float64   `spoc:"PCT_UTILIZED,nullzero"`
+	PctReclaim    float64   `spoc:"PCT_RECLAIM,nullzero"`
+	Status        string    `spoc:"STATUS,nullzero"`
+	Access        string    `spoc:"ACCESS,nullzero"`
+	Scratch       bool      `spoc:"SCRATCH,nullzero"`
+	ErrorState    bool      `spoc:"ERROR_STATE,nullzero"`
+	TimesMounted  int       `spoc:"TIMES_MOUNTED,nullzero"`
+	Location      string    `spoc:"LOCATION,nullzero"`
+	LastWrite     time.Time `spoc:"LAST_WRITE_DATE,nullzero"`
+	LastRead      time.Time `spoc:"LAST_READ_DATE,nullzero"`
+}
+
+// List the storage pool volumes of a backup server
+func (s *VolumesOp) List(ctx context.Context, serverName string, filter *VolumeFilter) ([]Volume, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if filter == nil {
+		filter = new(VolumeFilter)
+	}
+
+	return s.list(ctx, serverName,
+		sqlName("STGPOOL_NAME", filter.StoragePool),
+		sqlName("DEVCLASS_NAME", filter.DeviceClass),
+		sqlName("STATUS", filter.Status),
+		sqlName("ACCESS", filter.Access))
+}
+
+// Get the details of a specific volume
+func (s *VolumesOp) Get(ctx context.Context, serverName string, volumeName string) (*Volume, *http.Response, error) {
+	if serverName == "" {
+		return nil, nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if volumeName == "" {
+		return nil, nil, NewArgError("volumeName", "cannot be empty")
+	}
+
+	volumes, resp, err := s.list(ctx, serverName, sqlName("VOLUME_NAME", volumeName))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(volumes) == 0 {
+		return nil, resp, notFound("volume "+volumeName, serverName)
+	}
+
+	return &volumes[0], resp, err
+}
+
+func (s *VolumesOp) list(ctx context.Context, serverName string, conditions ...string) ([]Volume, *http.Response, error) {
+	var rows []volumeRow
+	resp, err := s.client.CLI.Query(ctx, serverName, selectFrom("VOLUMES", conditions...), &rows)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	volumes := make([]Volume, len(rows))
+	for i, row := range rows {
+		volumes[i] = Volume{
+			Name:         row.Name,
+			StoragePool:  row.StoragePool,
+			DeviceClass:  row.DeviceClass,
+			Capacity:     byteSizeFromMB(row.EstCapacityMB),
+			PctUtilized:  row.PctUtilized,
+			PctReclaim:   row.PctReclaim,
+			Status:       row.Status,
+			Access:       row.Access,
+			Scratch:      row.Scratch,
+			ErrorState:   row.ErrorState,
+			TimesMounted: row.TimesMounted,
+			Location:     row.Location,
+			LastWrite:    row.LastWrite,
+			LastRead:     row.LastRead,
+		}
+	}
+
+	return volumes, resp, err
+}
+
+// UpdateAccess changes the access mode of a volume, such as VolumeReadOnly or VolumeUnavailable
+func (s *VolumesOp) UpdateAccess(ctx context.Context, serverName string, volumeName string, access string) (*http.Response, error) {
+	if serverName == "" {
+		return nil, NewArgError("serverName", "cannot be empty")
+	}
+
+	if volumeName == "" {
+		return nil, NewArgError("volumeName", "cannot be empty")
+	}
+
+	switch access {
+	case VolumeReadWrite, VolumeReadOnly, VolumeUnavailable, VolumeDestroyed, VolumeOffsite:
+	default:
+		return nil, NewArgError("access", "must be a volume access mode")
+	}
+
+	cmd := newCommand("UPDATE", "VOLUME").arg(volumeName).param("ACCESS", access)
+
+	_, resp, err := s.client.runCommand(ctx, serverName, cmd)
+	return resp, err
+}